@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	backend "github.com/kumneger0/clispot/backend"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/history"
+	"github.com/kumneger0/clispot/internal/playlistio"
+	"github.com/kumneger0/clispot/internal/types"
+	ytMusicClient "github.com/kumneger0/clispot/internal/yt-music-client"
+	"github.com/spf13/cobra"
+)
+
+// the limit that fetches every track of a playlist in one request
+const allTracks = -1
+
+// connectBackend reuses the backend of a running clispot instance or starts a new one
+func connectBackend() (musicpb.MusicServiceClient, func(), error) {
+	client, conn, err := ytMusicClient.GetYtMusicClient("localhost:50051")
+	if err != nil {
+		return nil, nil, err
+	}
+	if isBackendHealthy(client, time.Second) {
+		return client, func() { _ = conn.Close() }, nil
+	}
+
+	backendCmd, err := backend.StartBackend(backend.PythonBacked)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = conn.Close()
+		if backendCmd != nil && backendCmd.Process != nil {
+			_ = backendCmd.Process.Signal(syscall.SIGTERM)
+		}
+	}
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		if isBackendHealthy(client, 2*time.Second) {
+			return client, cleanup, nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	cleanup()
+	return nil, nil, errors.New("the backend did not become ready in time")
+}
+
+func isBackendHealthy(client musicpb.MusicServiceClient, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := client.HealthCheck(ctx, &musicpb.HealthCheckRequest{})
+	return err == nil && resp.Ok
+}
+
+func songsToTracks(songs []*musicpb.Song) []*types.PlaylistTrackObject {
+	var tracks []*types.PlaylistTrackObject
+	for _, song := range songs {
+		tracks = append(tracks, &types.PlaylistTrackObject{
+			Track: types.MapSongToTrack(song),
+		})
+	}
+	return tracks
+}
+
+func newExportCmd() *cobra.Command {
+	var playlistID, albumID, output, format string
+	var liked, fromHistory bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "export a playlist, album, liked songs or the playback history as m3u8, xspf or json",
+		Example: `  clispot export --playlist PLxxxx -o mix.m3u8
+  clispot export --liked --format json > liked.json
+  clispot export --history -o history.xspf`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources := 0
+			for _, selected := range []bool{playlistID != "", albumID != "", liked, fromHistory} {
+				if selected {
+					sources++
+				}
+			}
+			if sources != 1 {
+				return errors.New("choose exactly one of --playlist, --album, --liked or --history")
+			}
+
+			exportFormat, err := resolveExportFormat(format, output)
+			if err != nil {
+				return err
+			}
+
+			var name string
+			var tracks []*types.PlaylistTrackObject
+			if fromHistory {
+				name = "History"
+				for _, entry := range history.New(history.DefaultPath(runtime.GOOS)).Recent(0) {
+					tracks = append(tracks, &types.PlaylistTrackObject{Track: entry.Track})
+				}
+			} else {
+				client, cleanup, err := connectBackend()
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				switch {
+				case playlistID != "":
+					resp, err := client.GetPlaylistItems(ctx, &musicpb.GetPlaylistItemsRequest{PlaylistId: playlistID, Limit: allTracks})
+					if err != nil {
+						return err
+					}
+					name, tracks = resp.Title, songsToTracks(resp.Tracks)
+				case albumID != "":
+					resp, err := client.GetAlbumTracks(ctx, &musicpb.GetAlbumTracksRequest{BrowseId: albumID})
+					if err != nil {
+						return err
+					}
+					name, tracks = resp.Title, songsToTracks(resp.Tracks)
+				case liked:
+					resp, err := client.GetUserSavedTracks(ctx, &musicpb.GetUserSavedTracksRequest{Limit: allTracks})
+					if err != nil {
+						return err
+					}
+					name, tracks = "Liked songs", songsToTracks(resp.Tracks)
+				}
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				file, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+			if err := playlistio.Encode(w, exportFormat, name, tracks); err != nil {
+				return err
+			}
+			if output != "" && output != "-" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported %d tracks to %s\n", len(tracks), output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&playlistID, "playlist", "", "id of the playlist to export")
+	cmd.Flags().StringVar(&albumID, "album", "", "browse id of the album to export")
+	cmd.Flags().BoolVar(&liked, "liked", false, "export the liked songs")
+	cmd.Flags().BoolVar(&fromHistory, "history", false, "export the playback history")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write to, defaults to stdout")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "m3u8, xspf or json, defaults to the extension of --output or m3u8")
+	return cmd
+}
+
+func resolveExportFormat(format, output string) (playlistio.Format, error) {
+	if format != "" {
+		return playlistio.ParseFormat(format)
+	}
+	if output != "" && output != "-" {
+		return playlistio.FormatFromPath(output)
+	}
+	return playlistio.M3U8, nil
+}
+
+func newImportCmd() *cobra.Command {
+	var name, format string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "import a m3u8, xspf or json playlist into a new youtube music playlist",
+		Long: `import a m3u8, xspf or json playlist into a new youtube music playlist.
+entries that reference a youtube video are used directly, everything else is matched by searching "artist - title".`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			importFormat, err := playlistio.FormatFromPath(path)
+			if format != "" {
+				importFormat, err = playlistio.ParseFormat(format)
+			}
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			entries, err := playlistio.Decode(file, importFormat)
+			if err != nil {
+				return err
+			}
+
+			client, cleanup, err := connectBackend()
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			report, err := playlistio.Resolve(ctx, client, entries)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "matched %d of %d tracks\n", len(report.Tracks), len(entries))
+			for _, miss := range report.Unmatched {
+				fmt.Fprintf(out, "  no match: %s (%s)\n", miss.Name(), miss.Reason)
+			}
+			if dryRun || len(report.Tracks) == 0 {
+				return nil
+			}
+
+			var videoIDs []string
+			for _, track := range report.Tracks {
+				videoIDs = append(videoIDs, track.Track.ID)
+			}
+			resp, err := client.CreatePlaylist(ctx, &musicpb.CreatePlaylistRequest{
+				Title:         name,
+				Description:   "imported from " + filepath.Base(path) + " by clispot",
+				PrivacyStatus: "PRIVATE",
+				VideoIds:      videoIDs,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "created playlist %q (%s)\n", name, resp.PlaylistId)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "name of the playlist to create, defaults to the file name")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "m3u8, xspf or json, defaults to the file extension")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only report which tracks could be matched")
+	return cmd
+}
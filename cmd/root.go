@@ -17,9 +17,15 @@ import (
 
 	"github.com/gofrs/flock"
 	backend "github.com/kumneger0/clispot/backend"
+	"github.com/kumneger0/clispot/internal/artwork"
+	"github.com/kumneger0/clispot/internal/cache"
 	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/filter"
 	"github.com/kumneger0/clispot/internal/headless"
+	"github.com/kumneger0/clispot/internal/history"
+	"github.com/kumneger0/clispot/internal/keymap"
 	logSetup "github.com/kumneger0/clispot/internal/logger"
+	"github.com/kumneger0/clispot/internal/theme"
 	"github.com/kumneger0/clispot/internal/youtube"
 	ytMusicClient "github.com/kumneger0/clispot/internal/yt-music-client"
 	"go.dalton.dog/bubbleup"
@@ -32,6 +38,7 @@ import (
 	"github.com/kumneger0/clispot/internal/mpris"
 	"github.com/kumneger0/clispot/internal/types"
 	"github.com/kumneger0/clispot/internal/ui"
+	"github.com/kumneger0/clispot/internal/undo"
 )
 
 var (
@@ -92,6 +99,8 @@ func newRootCmd(version string, debug bool) *cobra.Command {
 	cmd.AddCommand(clispotLog())
 	cmd.AddCommand(ManCmd(cmd))
 	cmd.AddCommand(installDeps())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newImportCmd())
 	return cmd
 }
 
@@ -222,14 +231,30 @@ func runRoot(cmd *cobra.Command) error {
 	}
 
 	config.SetConfig(&config.Config{
-		DebugDir:      &debugDir,
-		CacheDisabled: isCacheDisabled,
-		CacheDir:      &cacheDir,
-		YtDlpArgs:     &ytDlpArgs,
-		HeadlessMode:  isHeadlessMode,
-		SkipOnNoMatch: configFromFile.SkipOnNoMatch,
+		DebugDir:             &debugDir,
+		CacheDisabled:        isCacheDisabled,
+		CacheDir:             &cacheDir,
+		YtDlpArgs:            &ytDlpArgs,
+		HeadlessMode:         isHeadlessMode,
+		SkipOnNoMatch:        configFromFile.SkipOnNoMatch,
+		PreventDuplicates:    configFromFile.PreventDuplicates,
+		ChartsCountry:        configFromFile.ChartsCountry,
+		DisableMetadataCache: configFromFile.DisableMetadataCache,
+		ExplicitFilter:       configFromFile.ExplicitFilter,
+		LyricsDir:            configFromFile.LyricsDir,
+		Keymap:               configFromFile.Keymap,
+		Theme:                configFromFile.Theme,
+		ThemeOverrides:       configFromFile.ThemeOverrides,
+		AlbumArt:             configFromFile.AlbumArt,
+		Layout:               configFromFile.Layout,
 	})
 
+	keys, err := keymap.New(config.GetConfig().Keymap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid keymap in config.json:\n%v\n", err)
+		os.Exit(1)
+	}
+
 	logger := logSetup.Init(debugDir)
 	defer logger.Close()
 
@@ -280,6 +305,22 @@ func runRoot(cmd *cobra.Command) error {
 		os.Exit(1)
 	}
 	defer conn.Close()
+	explicitMode, err := filter.ParseExplicitMode(config.GetConfig().ExplicitFilter)
+	if err != nil {
+		slog.Warn(err.Error())
+	}
+	var cachedClient *cache.Client
+	if !config.GetConfig().DisableMetadataCache {
+		store := cache.New(cache.DefaultPath(runtime.GOOS))
+		// the responses stored since the last write are written on the way out
+		defer func() {
+			if err := store.Flush(); err != nil {
+				slog.Error(err.Error())
+			}
+		}()
+		cachedClient = cache.NewClient(client, store)
+		client = cachedClient
+	}
 	model := ui.Model{
 		BreadcrumbItems: []types.Breadcrumb{{Name: "Home", Icon: "⌂"}},
 		FocusedOn:       ui.SideView,
@@ -288,6 +329,12 @@ func runRoot(cmd *cobra.Command) error {
 		YtMusicClient:   client,
 		CoreDepsPath:    coreDepsPath,
 		BackendProcess:  backendCmd,
+		History:         history.New(history.DefaultPath(runtime.GOOS)),
+		SearchHistory:   history.NewSearch(history.SearchPath(runtime.GOOS)),
+		QueueHistory:    undo.New[[]list.Item](undo.DefaultDepth),
+		Filter:          filter.New(filter.DefaultPath(runtime.GOOS), explicitMode),
+		Keys:            keys,
+		Volume:          100,
 	}
 	model.SearchResult = list.New([]list.Item{}, ui.CustomDelegate{Model: &model}, 10, 20)
 	model.HomePageList = list.New([]list.Item{}, ui.CustomDelegate{Model: &model}, 10, 20)
@@ -298,7 +345,19 @@ func runRoot(cmd *cobra.Command) error {
 		headless.StartServer(&safeModel, messageChan)
 		return nil
 	}
-	sideBarItems := []struct{ name, icon string }{{name: "Home", icon: "⌂"}, {name: "Library", icon: ""}}
+	appTheme, err := theme.Load(config.GetConfig().Theme, theme.DefaultDir(runtime.GOOS), config.GetConfig().ThemeOverrides)
+	if err != nil {
+		slog.Error(err.Error())
+		appTheme = theme.Dark
+	}
+	ui.ApplyTheme(appTheme)
+	artProtocol, err := artwork.ParseProtocol(config.GetConfig().AlbumArt)
+	if err != nil {
+		slog.Warn(err.Error())
+	}
+	output := artwork.NewOutput(os.Stdout)
+	model.Artwork = ui.NewArtwork(artProtocol, artwork.NewCache(artwork.DefaultDir(runtime.GOOS)), output)
+	sideBarItems := []struct{ name, icon string }{{name: "Home", icon: "⌂"}, {name: "Explore", icon: "✦"}, {name: "Library", icon: ""}, {name: "Recently played", icon: "◷"}, {name: "Blocklist", icon: "⊘"}}
 	var SideBarMenuList []list.Item
 	for _, item := range sideBarItems {
 		SideBarMenuList = append(SideBarMenuList, types.SidebarItem{
@@ -317,13 +376,17 @@ func runRoot(cmd *cobra.Command) error {
 	model.Search = input
 	musicQueueList := list.New([]list.Item{}, ui.CustomDelegate{Model: &model}, 10, 20)
 	model.SideBarList = list.New(SideBarMenuList, ui.CustomDelegate{Model: &model}, 10, 20)
+	// left and right fold the library tree, so only page the sidebar with pgup/pgdown
+	model.SideBarList.KeyMap.NextPage.SetKeys("pgdown")
+	model.SideBarList.KeyMap.PrevPage.SetKeys("pgup")
+	model.Library = ui.NewLibrary()
 
 	model.SelectedPlayListItems = playlistItems
 	model.MusicQueueList = &ui.MusicQueueList{
 		Model:          musicQueueList,
 		PaginationInfo: nil,
 	}
-	Program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	Program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(output))
 
 	go func() {
 		if messageChan == nil {
@@ -334,6 +397,15 @@ func runRoot(cmd *cobra.Command) error {
 		}
 	}()
 
+	go func() {
+		if cachedClient == nil {
+			return
+		}
+		for msg := range cachedClient.Refreshed {
+			Program.Send(msg)
+		}
+	}()
+
 	go func() {
 		if types.PlayedSecondsUpdateChan == nil {
 			return
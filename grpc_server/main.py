@@ -15,7 +15,6 @@ from grpc_server.gen import music_pb2, music_pb2_grpc  # pyright: ignore[reportI
 from grpc_server.src.client.client import MusicClient  # pyright: ignore[reportImplicitRelativeImport]
 from grpc_server.src.client.types import (  # pyright: ignore[reportImplicitRelativeImport]
     YTHomeSection,
-    YTSearchResult,
     YTSong,
     YTThumbnail,
     YTArtist,
@@ -39,6 +38,18 @@ def _to_proto_artist(artist: YTArtist) -> music_pb2.Artist:
     )
 
 
+def _duration_seconds(length: str | None) -> int:
+    """Turns a length like "3:45" or "1:02:03" into seconds, 0 when it can not be parsed."""
+    if not length:
+        return 0
+    seconds = 0
+    for part in length.split(":"):
+        if not part.isdigit():
+            return 0
+        seconds = seconds * 60 + int(part)
+    return seconds
+
+
 def _to_proto_song(song: YTSong) -> music_pb2.Song:
     """Helper function to map a song dictionary from ytmusicapi to a Protobuf Song message."""
     album_name = ""
@@ -58,6 +69,7 @@ def _to_proto_song(song: YTSong) -> music_pb2.Song:
         duration_seconds=song.get("duration_seconds") or 0,
         liked=(song.get("likeStatus") == "LIKE"),
         is_explicit=bool(song.get("isExplicit")),
+        set_video_id=song.get("setVideoId") or "",
     )
 
     for artist in song.get("artists", []):
@@ -138,10 +150,10 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
 
     @override
     def GetUserSavedTracks(self, request: music_pb2.GetUserSavedTracksRequest, context: grpc.ServicerContext) -> music_pb2.GetUserSavedTracksResponse:
-        limit = request.limit if request.limit > 0 else 100
-        songs_data = self.client.get_user_saved_tracks(limit=limit)
+        limit = request.limit if request.limit != 0 else 100
+        songs_data, continuation = self.client.get_user_saved_tracks_page(limit=limit, continuation=request.continuation)
         songs_list = [_to_proto_song(song) for song in songs_data]
-        return music_pb2.GetUserSavedTracksResponse(tracks=songs_list, total=len(songs_list))
+        return music_pb2.GetUserSavedTracksResponse(tracks=songs_list, total=len(songs_list), continuation=continuation)
 
     @override
     def GetUserSavedAlbums(self, request: music_pb2.GetUserSavedAlbumsRequest, context: grpc.ServicerContext) -> music_pb2.GetUserSavedAlbumsResponse:
@@ -200,6 +212,8 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
             year=album_data.get("year") or "",
             total=album_data.get("trackCount") or 0,
             description=album_data.get("description") or "",
+            type=album_data.get("type") or "",
+            duration_seconds=album_data.get("duration_seconds") or 0,
         )
         for artist in album_data.get("artists", []):
             response.artists.append(_to_proto_artist(artist))
@@ -212,8 +226,8 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
 
     @override
     def GetPlaylistItems(self, request: music_pb2.GetPlaylistItemsRequest, context: grpc.ServicerContext) -> music_pb2.GetPlaylistItemsResponse:
-        limit = request.limit if request.limit > 0 else 100
-        playlist_data = self.client.get_playlist_items(playlist_id=request.playlist_id, limit=limit)
+        limit = request.limit if request.limit != 0 else 100
+        playlist_data, continuation = self.client.get_playlist_items_page(playlist_id=request.playlist_id, limit=limit, continuation=request.continuation)
         
         author_name = ""
         author_val = playlist_data.get("author")
@@ -228,6 +242,8 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
             author=author_name,
             year=playlist_data.get("year") or "",
             track_count=playlist_data.get("trackCount") or 0,
+            continuation=continuation,
+            owned=bool(playlist_data.get("owned")),
         )
         for thumbnail in playlist_data.get("thumbnails", []):
             response.thumbnails.append(_to_proto_thumbnail(thumbnail))
@@ -236,15 +252,63 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
             
         return response
 
+    @override
+    def CreatePlaylist(self, request: music_pb2.CreatePlaylistRequest, context: grpc.ServicerContext) -> music_pb2.CreatePlaylistResponse:
+        privacy_status = request.privacy_status if request.privacy_status in ("PRIVATE", "PUBLIC", "UNLISTED") else "PRIVATE"
+        playlist_id = self.client.create_playlist(
+            title=request.title,
+            description=request.description,
+            privacy_status=privacy_status,
+            video_ids=list(request.video_ids),
+        )
+        return music_pb2.CreatePlaylistResponse(playlist_id=playlist_id)
+
+    @override
+    def AddPlaylistItems(self, request: music_pb2.AddPlaylistItemsRequest, context: grpc.ServicerContext) -> music_pb2.AddPlaylistItemsResponse:
+        self.client.add_playlist_items(
+            playlist_id=request.playlist_id,
+            video_ids=list(request.video_ids),
+            allow_duplicates=request.allow_duplicates,
+        )
+        return music_pb2.AddPlaylistItemsResponse()
+
+    @override
+    def RemovePlaylistItems(self, request: music_pb2.RemovePlaylistItemsRequest, context: grpc.ServicerContext) -> music_pb2.RemovePlaylistItemsResponse:
+        self.client.remove_playlist_items(
+            playlist_id=request.playlist_id,
+            items=[(item.video_id, item.set_video_id) for item in request.items],
+        )
+        return music_pb2.RemovePlaylistItemsResponse()
+
+    @override
+    def EditPlaylist(self, request: music_pb2.EditPlaylistRequest, context: grpc.ServicerContext) -> music_pb2.EditPlaylistResponse:
+        privacy_status = request.privacy_status if request.privacy_status in ("PRIVATE", "PUBLIC", "UNLISTED") else None
+        self.client.edit_playlist(
+            playlist_id=request.playlist_id,
+            title=request.title or None,
+            description=request.description or None,
+            privacy_status=privacy_status,
+        )
+        return music_pb2.EditPlaylistResponse()
+
+    @override
+    def DeletePlaylist(self, request: music_pb2.DeletePlaylistRequest, context: grpc.ServicerContext) -> music_pb2.DeletePlaylistResponse:
+        self.client.delete_playlist(playlist_id=request.playlist_id)
+        return music_pb2.DeletePlaylistResponse()
+
     @override
     def GetSearchResults(self, request: music_pb2.GetSearchResultsRequest, context: grpc.ServicerContext) -> music_pb2.GetSearchResultsResponse:
         limit = request.limit if request.limit > 0 else 50
+        offset = max(request.offset, 0)
         filter_val: YTSearchFilter | None = None
         if request.filter in ("songs", "videos", "albums", "artists", "playlists"):
             filter_val = request.filter
         
-        raw_results: list[YTSearchResult] = self.client.get_search_results(query=request.query, filter_type=filter_val, limit=limit)
-        response: music_pb2.GetSearchResultsResponse = music_pb2.GetSearchResultsResponse()
+        raw_results, has_more = self.client.get_search_results_page(query=request.query, filter_type=filter_val, offset=offset, limit=limit)
+        # only a filtered search has more than its top results
+        response: music_pb2.GetSearchResultsResponse = music_pb2.GetSearchResultsResponse(
+            has_more=filter_val is not None and has_more,
+        )
         
         for result in raw_results:
             result_type = result.get("resultType")
@@ -325,13 +389,18 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
                     song_item.artists.append(_to_proto_artist(artist))
                 for thumbnail in result.get("thumbnails", []):
                     song_item.thumbnails.append(_to_proto_thumbnail(thumbnail))
-                response.songs.append(song_item)
+                response.videos.append(song_item)
 
         return response
 
+    @override
+    def GetSearchSuggestions(self, request: music_pb2.GetSearchSuggestionsRequest, context: grpc.ServicerContext) -> music_pb2.GetSearchSuggestionsResponse:
+        suggestions = self.client.get_search_suggestions(query=request.query)
+        return music_pb2.GetSearchSuggestionsResponse(suggestions=suggestions)
+
     @override
     def GetArtistTopTracks(self, request: music_pb2.GetArtistTopTracksRequest, context: grpc.ServicerContext) -> music_pb2.GetArtistTopTracksResponse:
-        artist_data = self.client.get_artist_top_tracks(channel_id=request.channel_id)
+        artist_data = self.client.get_artist(channel_id=request.channel_id)
         
         response = music_pb2.GetArtistTopTracksResponse(
             name=artist_data.get("name") or "",
@@ -347,6 +416,62 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
                 
         return response
 
+    @override
+    def GetArtist(self, request: music_pb2.GetArtistRequest, context: grpc.ServicerContext) -> music_pb2.GetArtistResponse:
+        artist_data = self.client.get_artist(channel_id=request.channel_id)
+        channel_id = artist_data.get("channelId") or request.channel_id
+        name = artist_data.get("name") or ""
+
+        response = music_pb2.GetArtistResponse(
+            channel_id=channel_id,
+            name=name,
+            description=artist_data.get("description") or "",
+            subscribers=artist_data.get("subscribers") or "",
+            is_followed=bool(artist_data.get("subscribed")),
+        )
+        for thumbnail in artist_data.get("thumbnails", []):
+            response.thumbnails.append(_to_proto_thumbnail(thumbnail))
+
+        for song in (artist_data.get("songs") or {}).get("results", []):
+            response.top_songs.append(_to_proto_song(song))
+        for video in (artist_data.get("videos") or {}).get("results", []):
+            response.videos.append(_to_proto_song(video))
+
+        # albums and singles on an artist page do not repeat the artist
+        page_artist = music_pb2.Artist(id=channel_id, name=name)
+        for album in (artist_data.get("albums") or {}).get("results", []):
+            album_msg = _to_proto_album(album)
+            if not album_msg.artists:
+                album_msg.artists.append(page_artist)
+            response.albums.append(album_msg)
+        for single in (artist_data.get("singles") or {}).get("results", []):
+            single_msg = _to_proto_album(single)
+            if not single_msg.artists:
+                single_msg.artists.append(page_artist)
+            response.singles.append(single_msg)
+
+        for related in (artist_data.get("related") or {}).get("results", []):
+            related_msg = music_pb2.FollowedArtist(
+                channel_id=related.get("browseId") or "",
+                name=related.get("title") or "",
+                subscribers=related.get("subscribers") or "",
+            )
+            for thumbnail in related.get("thumbnails", []):
+                related_msg.thumbnails.append(_to_proto_thumbnail(thumbnail))
+            response.related.append(related_msg)
+
+        return response
+
+    @override
+    def FollowArtist(self, request: music_pb2.FollowArtistRequest, context: grpc.ServicerContext) -> music_pb2.FollowArtistResponse:
+        _ = self.client.follow_artists(list(request.channel_ids))
+        return music_pb2.FollowArtistResponse()
+
+    @override
+    def UnfollowArtist(self, request: music_pb2.UnfollowArtistRequest, context: grpc.ServicerContext) -> music_pb2.UnfollowArtistResponse:
+        _ = self.client.unfollow_artists(list(request.channel_ids))
+        return music_pb2.UnfollowArtistResponse()
+
     @override
     def GetFollowedArtists(self, request: music_pb2.GetFollowedArtistsRequest, context: grpc.ServicerContext) -> music_pb2.GetFollowedArtistsResponse:
         limit = request.limit if request.limit > 0 else 25
@@ -409,6 +534,11 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
     def UnlikeSong(self, request: music_pb2.UnlikeSongRequest, context: grpc.ServicerContext) -> music_pb2.UnlikeSongResponse:
         _ = self.client.unlike_song(request.video_id)
         return music_pb2.UnlikeSongResponse()
+
+    @override
+    def DislikeSong(self, request: music_pb2.DislikeSongRequest, context: grpc.ServicerContext) -> music_pb2.DislikeSongResponse:
+        _ = self.client.dislike_song(request.video_id)
+        return music_pb2.DislikeSongResponse()
     @override
     def GetVideoStreamURL(self, request: music_pb2.GetVideoStreamURLRequest, context:grpc.ServicerContext) -> music_pb2.GetVideoStreamURLResponse:
         stream_url: str = self.client.get_stream_url(request.videoId)
@@ -442,6 +572,112 @@ class MusicService(music_pb2_grpc.MusicServiceServicer): # type: ignore
         
         return response
 
+    @override
+    def GetExplore(self, request: music_pb2.GetExploreRequest, context: grpc.ServicerContext) -> music_pb2.GetExploreResponse:
+        explore = self.client.get_explore()
+        response = music_pb2.GetExploreResponse()
+        for album in explore.get("new_releases", []):
+            response.new_releases.append(_to_proto_album(album))
+        trending = explore.get("trending") or explore.get("top_songs") or {}
+        response.trending_playlist_id = trending.get("playlist") or ""
+        for song in trending.get("items", []):
+            response.trending.append(_to_proto_song(song))
+        return response
+
+    @override
+    def GetCharts(self, request: music_pb2.GetChartsRequest, context: grpc.ServicerContext) -> music_pb2.GetChartsResponse:
+        charts = self.client.get_charts(country=request.country.upper())
+        countries = charts.get("countries") or {}
+        response = music_pb2.GetChartsResponse(
+            country=(countries.get("selected") or {}).get("text") or "",
+        )
+        response.countries.extend(countries.get("options") or [])
+
+        videos = charts.get("videos") or []
+        if isinstance(videos, dict):
+            # older ytmusicapi versions return a single "top videos" playlist
+            playlist_id = videos.get("playlist") or ""
+            if playlist_id:
+                response.playlists.append(music_pb2.Playlist(playlist_id=playlist_id, title="Top music videos"))
+        else:
+            for playlist in videos:
+                response.playlists.append(_to_proto_playlist(playlist))
+        for playlist in charts.get("genres") or []:
+            response.playlists.append(_to_proto_playlist(playlist))
+
+        artists = charts.get("artists") or []
+        if isinstance(artists, dict):
+            artists = artists.get("items") or []
+        for index, artist in enumerate(artists):
+            rank = artist.get("rank") or ""
+            artist_msg = music_pb2.ChartArtist(
+                channel_id=artist.get("browseId") or "",
+                name=artist.get("title") or "",
+                subscribers=artist.get("subscribers") or "",
+                rank=int(rank) if rank.isdigit() else index + 1,
+                trend=artist.get("trend") or "",
+            )
+            for thumbnail in artist.get("thumbnails", []):
+                artist_msg.thumbnails.append(_to_proto_thumbnail(thumbnail))
+            response.artists.append(artist_msg)
+        return response
+
+    @override
+    def GetMoodCategories(self, request: music_pb2.GetMoodCategoriesRequest, context: grpc.ServicerContext) -> music_pb2.GetMoodCategoriesResponse:
+        response = music_pb2.GetMoodCategoriesResponse()
+        for title, categories in self.client.get_mood_categories().items():
+            section_msg = music_pb2.MoodSection(title=title)
+            for category in categories:
+                section_msg.categories.append(music_pb2.MoodCategory(
+                    title=category.get("title") or "",
+                    params=category.get("params") or "",
+                ))
+            response.sections.append(section_msg)
+        return response
+
+    @override
+    def GetMoodPlaylists(self, request: music_pb2.GetMoodPlaylistsRequest, context: grpc.ServicerContext) -> music_pb2.GetMoodPlaylistsResponse:
+        response = music_pb2.GetMoodPlaylistsResponse()
+        for playlist in self.client.get_mood_playlists(params=request.params):
+            response.playlists.append(_to_proto_playlist(playlist))
+        return response
+
+    @override
+    def GetRadio(self, request: music_pb2.GetRadioRequest, context: grpc.ServicerContext) -> music_pb2.GetRadioResponse:
+        watch = self.client.get_radio(video_id=request.video_id, limit=request.limit or 25)
+        response = music_pb2.GetRadioResponse(playlist_id=watch.get("playlistId") or "")
+        for track in watch.get("tracks", []):
+            song: YTSong = {
+                "videoId": track.get("videoId"),
+                "title": track.get("title") or "",
+                "artists": track.get("artists") or [],
+                "album": track.get("album"),
+                "duration_seconds": _duration_seconds(track.get("length")),
+                "likeStatus": track.get("likeStatus"),
+                "thumbnails": track.get("thumbnail") or [],
+                "isExplicit": track.get("isExplicit"),
+            }
+            response.tracks.append(_to_proto_song(song))
+        return response
+
+    @override
+    def GetLyrics(self, request: music_pb2.GetLyricsRequest, context: grpc.ServicerContext) -> music_pb2.GetLyricsResponse:
+        lyrics = self.client.get_lyrics(video_id=request.video_id)
+        if not lyrics:
+            return music_pb2.GetLyricsResponse(found=False)
+        response = music_pb2.GetLyricsResponse(found=True, source=lyrics.get("source") or "")
+        raw_lyrics = lyrics.get("lyrics") or ""
+        if isinstance(raw_lyrics, str):
+            response.text = raw_lyrics
+            return response
+        for line in raw_lyrics:
+            response.lines.append(music_pb2.LyricLine(
+                text=line.text or "",
+                start_ms=line.start_time or 0,
+                end_ms=line.end_time or 0,
+            ))
+        return response
+
 
 
 def make_shutdown_handler(server: grpc.Server) -> Callable[..., None]:
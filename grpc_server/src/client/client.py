@@ -1,4 +1,6 @@
-from typing import cast
+from collections import OrderedDict
+from threading import Lock
+from typing import Callable, cast
 from ytmusicapi import YTMusic, LikeStatus
 from yt_dlp import YoutubeDL
 
@@ -14,14 +16,68 @@ from .types import (
     YTSongResponse,
     YTArtistResponse,
     YTSearchResult,
-    YTSearchFilter
+    YTSearchFilter,
+    YTExploreResponse,
+    YTChartsResponse,
+    YTMoodCategory,
+    YTWatchPlaylist,
+    YTWatchLyrics,
+    YTLyrics,
 )
 
+
+# ytmusicapi follows the continuations of a playlist itself and does not hand them out, so the first page
+# fetches the whole playlist once and keeps it, the tokens given to clients are the offset of the next page
+# into it. clients treat them as opaque.
+_CACHED_PLAYLISTS = 16
+
+# ytmusicapi.search follows the continuations of a filtered search up to its limit and does not hand them out
+# either, so the results of a search are kept and "load more" past them searches again for twice as many,
+# which keeps the requests of paging through a search linear in the results shown.
+_CACHED_SEARCHES = 16
+
+
+def _continuation_offset(continuation: str) -> int:
+    return int(continuation) if continuation.isdigit() else 0
+
+
+# a negative limit returns every track from offset on
+def _page(tracks: list[YTSong], offset: int, limit: int) -> tuple[list[YTSong], str]:
+    if limit < 0:
+        return tracks[offset:], ""
+    page = tracks[offset:offset + limit]
+    next_continuation = str(offset + limit) if len(tracks) > offset + limit else ""
+    return page, next_continuation
+
+
 class MusicClient:
     client: YTMusic
+    _playlists: OrderedDict[str, YTLikedSongsResponse]
+    _cache_lock: Lock
+    # the results of a search keyed by its filter and query, and whether they are all of them
+    _searches: OrderedDict[str, tuple[list[YTSearchResult], bool]]
 
     def __init__(self, auth_file: str) -> None:
         self.client = YTMusic(auth_file)
+        self._playlists = OrderedDict()
+        self._cache_lock = Lock()
+        self._searches = OrderedDict()
+
+    # _cached_playlist fetches the playlist again for its first page so a reopened playlist is up to date,
+    # the later pages are cut from the copy kept for the first one
+    def _cached_playlist(self, key: str, continuation: str, fetch: Callable[[], YTLikedSongsResponse]) -> YTLikedSongsResponse:
+        with self._cache_lock:
+            playlist = self._playlists.get(key) if continuation else None
+            if playlist is not None:
+                self._playlists.move_to_end(key)
+                return playlist
+        playlist = fetch()
+        with self._cache_lock:
+            self._playlists[key] = playlist
+            self._playlists.move_to_end(key)
+            while len(self._playlists) > _CACHED_PLAYLISTS:
+                _ = self._playlists.popitem(last=False)
+        return playlist
     
     def get_stream_url(self, video_id:str) -> str:
         full_url: str = "https://www.youtube.com/watch?v=" + video_id
@@ -43,6 +99,34 @@ class MusicClient:
         res: object = self.client.get_home()
         return cast(list[YTHomeSection], res)
 
+    def get_explore(self) -> YTExploreResponse:
+        raw_explore: object = self.client.get_explore()
+        return cast(YTExploreResponse, raw_explore)
+
+    def get_charts(self, country: str) -> YTChartsResponse:
+        raw_charts: object = self.client.get_charts(country=country or "ZZ")
+        return cast(YTChartsResponse, raw_charts)
+
+    def get_mood_categories(self) -> dict[str, list[YTMoodCategory]]:
+        raw_categories: object = self.client.get_mood_categories()
+        return cast(dict[str, list[YTMoodCategory]], raw_categories)
+
+    def get_mood_playlists(self, params: str) -> list[YTLibraryPlaylist]:
+        raw_playlists: object = self.client.get_mood_playlists(params)
+        return cast(list[YTLibraryPlaylist], raw_playlists)
+
+    def get_radio(self, video_id: str, limit: int = 25) -> YTWatchPlaylist:
+        raw_watch: object = self.client.get_watch_playlist(videoId=video_id, radio=True, limit=limit)
+        return cast(YTWatchPlaylist, raw_watch)
+
+    def get_lyrics(self, video_id: str) -> YTLyrics | None:
+        raw_watch: object = self.client.get_watch_playlist(videoId=video_id, limit=1)
+        browse_id = cast(YTWatchLyrics, raw_watch).get("lyrics")
+        if not browse_id:
+            return None
+        raw_lyrics: object = self.client.get_lyrics(browseId=browse_id, timestamps=True)
+        return cast(YTLyrics | None, raw_lyrics)
+
     def get_library(self, limit: int = 25) -> list[YTSong]:
         return self.get_user_saved_tracks(limit)
 
@@ -54,6 +138,17 @@ class MusicClient:
             return tracks
         return []
 
+    def get_user_saved_tracks_page(self, limit: int, continuation: str) -> tuple[list[YTSong], str]:
+        def fetch() -> YTLikedSongsResponse:
+            raw_songs: object = self.client.get_liked_songs(limit=None)  # pyright: ignore[reportArgumentType]
+            return cast(YTLikedSongsResponse, cast(object, raw_songs))
+
+        songs = self._cached_playlist("liked", continuation, fetch)
+        tracks = songs.get('tracks')
+        if not isinstance(tracks, list):
+            return [], ""
+        return _page(tracks, _continuation_offset(continuation), limit)
+
     def get_user_saved_albums(self, limit: int = 25) -> list[YTLibraryAlbum]:
         raw_albums: object = self.client.get_library_albums(limit=limit)
         return cast(list[YTLibraryAlbum], raw_albums)
@@ -81,18 +176,102 @@ class MusicClient:
         raw_album: object = self.client.get_album(browseId=browse_id)
         return cast(YTAlbumResponse, cast(object, raw_album))
 
-    def get_playlist_items(self, playlist_id: str, limit: int = 100) -> YTLikedSongsResponse:
+    def get_playlist_items(self, playlist_id: str, limit: int | None = 100) -> YTLikedSongsResponse:
         raw_playlist: object = self.client.get_playlist(playlistId=playlist_id, limit=limit)
         return cast(YTLikedSongsResponse, cast(object, raw_playlist))
 
+    def get_playlist_items_page(self, playlist_id: str, limit: int, continuation: str) -> tuple[YTLikedSongsResponse, str]:
+        playlist = self._cached_playlist("playlist:" + playlist_id, continuation, lambda: self.get_playlist_items(playlist_id, limit=None))
+        tracks, next_continuation = _page(playlist.get("tracks", []), _continuation_offset(continuation), limit)
+        page = cast(YTLikedSongsResponse, {**playlist, "tracks": tracks})
+        return page, next_continuation
+
+    def create_playlist(self, title: str, description: str, privacy_status: str, video_ids: list[str]) -> str:
+        res: object = self.client.create_playlist(
+            title=title,
+            description=description,
+            privacy_status=privacy_status,
+            video_ids=video_ids or None,
+        )
+        if not isinstance(res, str):
+            raise RuntimeError(f"Unable to create playlist: {res}")
+        return res
+
+    def add_playlist_items(self, playlist_id: str, video_ids: list[str], allow_duplicates: bool = False) -> None:
+        res: object = self.client.add_playlist_items(
+            playlistId=playlist_id,
+            videoIds=video_ids,
+            duplicates=allow_duplicates,
+        )
+        if isinstance(res, dict) and res.get("status") not in (None, "STATUS_SUCCEEDED"):
+            raise RuntimeError(f"Unable to add items to playlist: {res.get('status')}")
+
+    def remove_playlist_items(self, playlist_id: str, items: list[tuple[str, str]]) -> None:
+        """Removes (videoId, setVideoId) pairs from a playlist, a missing setVideoId is looked up from the playlist."""
+        videos: list[dict[str, str]] = [{"videoId": video_id, "setVideoId": set_video_id} for video_id, set_video_id in items if set_video_id]
+        missing = {video_id for video_id, set_video_id in items if not set_video_id}
+        if missing:
+            playlist = self.get_playlist_items(playlist_id, limit=None)
+            for track in playlist.get("tracks", []):
+                video_id = track.get("videoId")
+                set_video_id = track.get("setVideoId")
+                if video_id in missing and set_video_id:
+                    videos.append({"videoId": video_id, "setVideoId": set_video_id})
+        if not videos:
+            raise RuntimeError("None of the tracks are in the playlist")
+        res: object = self.client.remove_playlist_items(playlistId=playlist_id, videos=videos)
+        if res != "STATUS_SUCCEEDED" and not (isinstance(res, dict) and res.get("status") == "STATUS_SUCCEEDED"):
+            raise RuntimeError(f"Unable to remove items from playlist: {res}")
+
+    def edit_playlist(self, playlist_id: str, title: str | None, description: str | None, privacy_status: str | None) -> None:
+        res: object = self.client.edit_playlist(
+            playlistId=playlist_id,
+            title=title,
+            description=description,
+            privacyStatus=privacy_status,
+        )
+        if res != "STATUS_SUCCEEDED" and not (isinstance(res, dict) and res.get("status") == "STATUS_SUCCEEDED"):
+            raise RuntimeError(f"Unable to edit playlist: {res}")
+
+    def delete_playlist(self, playlist_id: str) -> None:
+        _ = self.client.delete_playlist(playlistId=playlist_id)
+
     def get_search_results(self, query: str, filter_type: YTSearchFilter | None = None, limit: int = 20) -> list[YTSearchResult]:
         raw_results: object = self.client.search(query=query, filter=filter_type, limit=limit)
         return cast(list[YTSearchResult], raw_results)
 
-    def get_artist_top_tracks(self, channel_id: str) -> YTArtistResponse:
+    # get_search_results_page returns limit results from offset on and whether more follow them
+    def get_search_results_page(self, query: str, filter_type: YTSearchFilter | None, offset: int, limit: int) -> tuple[list[YTSearchResult], bool]:
+        key = f"{filter_type or ''}:{query}"
+        wanted = offset + limit + 1
+        with self._cache_lock:
+            cached = self._searches.get(key) if offset else None
+        if cached is None or (len(cached[0]) < wanted and not cached[1]):
+            fetch = wanted if cached is None else max(wanted, 2 * len(cached[0]))
+            results = self.get_search_results(query=query, filter_type=filter_type, limit=fetch)
+            cached = (results, len(results) < fetch)
+            with self._cache_lock:
+                self._searches[key] = cached
+                self._searches.move_to_end(key)
+                while len(self._searches) > _CACHED_SEARCHES:
+                    _ = self._searches.popitem(last=False)
+        results = cached[0]
+        return results[offset:offset + limit], len(results) > offset + limit
+
+    def get_search_suggestions(self, query: str) -> list[str]:
+        raw_suggestions: object = self.client.get_search_suggestions(query)
+        return cast(list[str], raw_suggestions)
+
+    def get_artist(self, channel_id: str) -> YTArtistResponse:
         raw_artist: object = self.client.get_artist(channelId=channel_id)
         return cast(YTArtistResponse, cast(object, raw_artist))
 
+    def follow_artists(self, channel_ids: list[str]) -> object:
+        return self.client.subscribe_artists(channelIds=channel_ids)
+
+    def unfollow_artists(self, channel_ids: list[str]) -> object:
+        return self.client.unsubscribe_artists(channelIds=channel_ids)
+
     def get_followed_artists(self, limit: int = 25) -> list[YTLibraryArtist]:
         raw_artists: object = self.client.get_library_subscriptions(limit=limit)
         return cast(list[YTLibraryArtist], raw_artists)
@@ -143,4 +322,10 @@ class MusicClient:
         return self.client.rate_song(
             video_id,
             LikeStatus.INDIFFERENT
+        )
+
+    def dislike_song(self, video_id: str) -> object:
+        return self.client.rate_song(
+            video_id,
+            LikeStatus.DISLIKE
         )
\ No newline at end of file
@@ -2,6 +2,8 @@
 
 from typing import TypedDict, Literal
 
+from ytmusicapi.models.lyrics import LyricLine
+
 # Search filters supported by ytmusicapi
 YTSearchFilter = Literal["songs", "videos", "albums", "artists", "playlists"]
 
@@ -36,6 +38,7 @@ class YTSong(TypedDict, total=False):
     isExplicit: bool | None
     isAvailable: bool | None
     played: str | None  # present in history items
+    setVideoId: str | None  # present in playlist items
 
 
 class YTLikedSongsResponse(TypedDict, total=False):
@@ -50,6 +53,7 @@ class YTLikedSongsResponse(TypedDict, total=False):
     duration_seconds: int | None
     thumbnails: list[YTThumbnail]
     tracks: list[YTSong]
+    owned: bool
 
 
 class YTLibraryAlbum(TypedDict, total=False):
@@ -94,14 +98,37 @@ class YTArtistSongsSection(TypedDict, total=False):
     results: list[YTSong]
 
 
+class YTArtistAlbumsSection(TypedDict, total=False):
+    browseId: str | None
+    results: list[YTLibraryAlbum]
+
+
+class YTArtistRelated(TypedDict, total=False):
+    """A related artist as listed on an artist page."""
+    browseId: str
+    title: str
+    subscribers: str | None
+    thumbnails: list[YTThumbnail]
+
+
+class YTArtistRelatedSection(TypedDict, total=False):
+    browseId: str | None
+    results: list[YTArtistRelated]
+
+
 class YTArtistResponse(TypedDict, total=False):
     """Return type of get_artist."""
     name: str
     channelId: str | None
     description: str | None
     subscribers: str | None
+    subscribed: bool | None
     thumbnails: list[YTThumbnail]
     songs: YTArtistSongsSection
+    albums: YTArtistAlbumsSection
+    singles: YTArtistAlbumsSection
+    videos: YTArtistSongsSection
+    related: YTArtistRelatedSection
 
 
 class YTLibraryArtist(TypedDict, total=False):
@@ -145,6 +172,76 @@ class YTHomeSection(TypedDict, total=False):
     contents: list[dict[str, object]]
 
 
+class YTTrendingSection(TypedDict, total=False):
+    playlist: str | None
+    items: list[YTSong]
+
+
+class YTExploreResponse(TypedDict, total=False):
+    """Return type of get_explore."""
+    new_releases: list[YTLibraryAlbum]
+    trending: YTTrendingSection
+    top_songs: YTTrendingSection  # the name older ytmusicapi versions use for trending
+
+
+class YTChartArtist(TypedDict, total=False):
+    title: str
+    browseId: str | None
+    subscribers: str | None
+    thumbnails: list[YTThumbnail]
+    rank: str | None
+    trend: str | None
+
+
+class YTChartCountries(TypedDict, total=False):
+    selected: dict[str, str]
+    options: list[str]
+
+
+class YTChartsResponse(TypedDict, total=False):
+    """Return type of get_charts, older ytmusicapi versions return sections instead of lists."""
+    countries: YTChartCountries
+    videos: list[YTLibraryPlaylist] | YTTrendingSection
+    genres: list[YTLibraryPlaylist]
+    artists: list[YTChartArtist] | dict[str, list[YTChartArtist]]
+
+
+class YTWatchTrack(TypedDict, total=False):
+    """A track of get_watch_playlist, it names a few fields differently than YTSong."""
+    videoId: str
+    title: str
+    length: str | None  # "3:45"
+    thumbnail: list[YTThumbnail]
+    artists: list[YTArtist]
+    album: YTAlbumInfo | None
+    likeStatus: str | None
+    isExplicit: bool | None
+
+
+class YTWatchPlaylist(TypedDict, total=False):
+    """Return type of get_watch_playlist."""
+    playlistId: str | None
+    tracks: list[YTWatchTrack]
+
+
+class YTWatchLyrics(TypedDict, total=False):
+    """The part of get_watch_playlist that points to the lyrics of the track."""
+    lyrics: str | None  # browseId of the lyrics, None when there are none
+
+
+class YTLyrics(TypedDict, total=False):
+    """Return type of get_lyrics, lyrics is a string unless hasTimestamps is set, then its lines are LyricLine
+    dataclasses with start_time and end_time in milliseconds."""
+    lyrics: str | list[LyricLine]
+    source: str | None
+    hasTimestamps: bool
+
+
+class YTMoodCategory(TypedDict, total=False):
+    title: str
+    params: str
+
+
 class YTSongResponse(TypedDict, total=False):
     """Return type of get_song (videoDetails sub-dict)."""
     videoId: str
@@ -0,0 +1,216 @@
+package artwork
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// Protocol is how images are drawn in the terminal
+type Protocol string
+
+const (
+	// Auto picks the protocol from the environment the terminal sets
+	Auto Protocol = ""
+	Off  Protocol = "off"
+	// Blocks draws two pixels per cell with "▀", it works on every terminal with colours
+	Blocks Protocol = "blocks"
+	Kitty  Protocol = "kitty"
+	Sixel  Protocol = "sixel"
+)
+
+// ParseProtocol reads "album-art" of config.json, an empty value or "auto" is Auto
+func ParseProtocol(value string) (Protocol, error) {
+	switch protocol := Protocol(strings.ToLower(strings.TrimSpace(value))); protocol {
+	case Auto, Off, Blocks, Kitty, Sixel:
+		return protocol, nil
+	case "auto":
+		return Auto, nil
+	}
+	return Auto, fmt.Errorf("unknown album-art %q, expected auto, kitty, sixel, blocks or off", value)
+}
+
+// Detect guesses the best protocol the terminal supports, getenv is os.Getenv
+func Detect(getenv func(string) string) Protocol {
+	term := getenv("TERM")
+	program := getenv("TERM_PROGRAM")
+	// tmux and screen do not pass the image escape sequences through by default
+	if getenv("TMUX") != "" || strings.HasPrefix(term, "screen") {
+		return Blocks
+	}
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty", program == "ghostty":
+		return Kitty
+	case program == "WezTerm", program == "iTerm.app", strings.HasPrefix(term, "foot"), strings.Contains(term, "mlterm"),
+		strings.Contains(term, "contour"), strings.Contains(term, "sixel"):
+		return Sixel
+	}
+	return Blocks
+}
+
+// DefaultDir is where downloaded images are kept
+func DefaultDir(goos string) string {
+	return filepath.Join(config.GetCacheDir(goos), "artwork")
+}
+
+// MaxFiles keeps the image cache from growing forever, the images downloaded the longest ago are dropped first
+const MaxFiles = 300
+
+// Cache downloads images once and keeps them on disk keyed by their url
+type Cache struct {
+	dir    string
+	client *http.Client
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16]))
+}
+
+// Get decodes the image at url, from disk when it was downloaded before
+func (c *Cache) Get(ctx context.Context, url string) (image.Image, error) {
+	path := c.path(url)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		data, err = c.download(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.store(path, data); err != nil {
+			// the image can still be shown, it is only downloaded again next time
+			slog.Error("failed to cache the artwork", "err", err)
+		}
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		// the file would fail the same way every time, it is downloaded again instead
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			slog.Error("failed to remove the undecodable artwork", "err", err)
+		}
+		return nil, fmt.Errorf("failed to decode the artwork: %w", err)
+	}
+	return img, nil
+}
+
+func (c *Cache) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// webp can not be decoded without another dependency
+	req.Header.Set("Accept", "image/jpeg,image/png")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download the artwork: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+}
+
+func (c *Cache) store(path string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	return c.prune()
+}
+
+func (c *Cache) prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil || len(entries) <= MaxFiles {
+		return err
+	}
+	type file struct {
+		name    string
+		modTime time.Time
+	}
+	var files []file
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		files = append(files, file{entry.Name(), info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files[:max(len(files)-MaxFiles, 0)] {
+		if err := os.Remove(filepath.Join(c.dir, f.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pick is the url of the smallest image at least width pixels wide, the largest one when none is
+func Pick(images []types.Image, width int) string {
+	if len(images) == 0 {
+		return ""
+	}
+	sorted := append([]types.Image(nil), images...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Width < sorted[j].Width })
+	for _, img := range sorted {
+		if img.Width >= width {
+			return img.URL
+		}
+	}
+	return sorted[len(sorted)-1].URL
+}
+
+// Resize scales img to width by height pixels, averaging the source pixels that fall in every target pixel
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := image.NewRGBA(img.Bounds())
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if bounds.Empty() {
+		return dst
+	}
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					p := src.RGBAAt(sx, sy)
+					r, g, b, a = r+int(p.R), g+int(p.G), b+int(p.B), a+int(p.A)
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n)
+		}
+	}
+	return dst
+}
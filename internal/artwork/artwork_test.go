@@ -0,0 +1,159 @@
+package artwork
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func solid(width, height int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestParseProtocol(t *testing.T) {
+	for value, want := range map[string]Protocol{"": Auto, "auto": Auto, "Kitty": Kitty, " sixel ": Sixel, "blocks": Blocks, "off": Off} {
+		protocol, err := ParseProtocol(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, protocol, value)
+	}
+	_, err := ParseProtocol("iterm")
+	assert.ErrorContains(t, err, `unknown album-art "iterm"`)
+}
+
+func TestDetect(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	assert.Equal(t, Kitty, Detect(env(map[string]string{"TERM": "xterm-kitty"})))
+	assert.Equal(t, Kitty, Detect(env(map[string]string{"TERM_PROGRAM": "ghostty"})))
+	assert.Equal(t, Sixel, Detect(env(map[string]string{"TERM_PROGRAM": "WezTerm"})))
+	assert.Equal(t, Sixel, Detect(env(map[string]string{"TERM": "foot"})))
+	assert.Equal(t, Blocks, Detect(env(map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"})))
+	assert.Equal(t, Blocks, Detect(env(map[string]string{"TERM": "xterm-256color"})))
+}
+
+func TestPick(t *testing.T) {
+	images := []types.Image{{URL: "large", Width: 544}, {URL: "small", Width: 60}, {URL: "medium", Width: 226}}
+	assert.Equal(t, "medium", Pick(images, 200))
+	assert.Equal(t, "small", Pick(images, 10))
+	assert.Equal(t, "large", Pick(images, 1000))
+	assert.Equal(t, "", Pick(nil, 100))
+}
+
+func TestResize(t *testing.T) {
+	img := solid(4, 4, color.RGBA{R: 255, A: 255})
+	for x := 2; x < 4; x++ {
+		for y := range 4 {
+			img.SetRGBA(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	resized := Resize(img, 2, 1)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, resized.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, resized.RGBAAt(1, 0))
+}
+
+func TestHalfBlocks(t *testing.T) {
+	rendered := HalfBlocks(solid(10, 10, color.RGBA{G: 255, A: 255}), 6, 3)
+	lines := strings.Split(rendered, "\n")
+	assert.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.Equal(t, 6, lipgloss.Width(line))
+	}
+}
+
+func TestSixelCells(t *testing.T) {
+	rendered := SixelCells(solid(10, 10, color.RGBA{R: 255, A: 255}), 4, 2, 2, 6)
+	lines := strings.Split(rendered, "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "\x1b7\x1bPq\"1;1;8;12"))
+	assert.Contains(t, lines[0], "#180;2;100;0;0")
+	// two bands of six rows, each one run of 8 full columns
+	assert.Equal(t, 2, strings.Count(lines[0], "!8~"))
+	assert.True(t, strings.HasSuffix(lines[0], "\x1b\\\x1b8    "))
+	assert.Equal(t, "    ", lines[1])
+}
+
+func TestKitty(t *testing.T) {
+	chunks, err := KittyTransmit(7, solid(10, 10, color.RGBA{A: 255}), 3, 2, 10, 20)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, chunks)
+	assert.True(t, strings.HasPrefix(chunks[0], "\x1b_Ga=T,U=1,q=2,f=100,i=7,c=3,r=2,m="))
+	assert.True(t, strings.HasSuffix(chunks[len(chunks)-1], "\x1b\\"))
+
+	lines := strings.Split(KittyPlaceholders(7, 3, 2), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "\x1b[38;2;0;0;7m"))
+	assert.Equal(t, 3, strings.Count(lines[1], string(kittyPlaceholder)))
+	assert.Contains(t, lines[1], string([]rune{kittyPlaceholder, kittyDiacritics[1], kittyDiacritics[2]}))
+
+	assert.Equal(t, "\x1b_Ga=d,d=I,i=7,q=2\x1b\\", KittyDelete(7))
+}
+
+func TestCache(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, solid(3, 3, color.RGBA{R: 255, A: 255})))
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	cache := NewCache(t.TempDir())
+	for range 2 {
+		img, err := cache.Get(context.Background(), server.URL+"/cover.png")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, img.Bounds().Dx())
+	}
+	assert.Equal(t, 1, requests)
+
+	_, err := cache.Get(context.Background(), server.URL+"/missing")
+	assert.ErrorContains(t, err, "404")
+
+	// a file that can not be decoded is removed so the image is downloaded again
+	broken := server.URL + "/broken.png"
+	assert.NoError(t, os.WriteFile(cache.path(broken), []byte("not an image"), 0644))
+	_, err = cache.Get(context.Background(), broken)
+	assert.ErrorContains(t, err, "decode")
+	assert.NoFileExists(t, cache.path(broken))
+	img, err := cache.Get(context.Background(), broken)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, img.Bounds().Dx())
+}
+
+func TestOutput(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "output")
+	assert.NoError(t, err)
+	out := NewOutput(file)
+	assert.Equal(t, file.Fd(), out.Fd())
+
+	_, err = out.Write([]byte("frame"))
+	assert.NoError(t, err)
+	assert.NoError(t, out.WriteAll([]string{KittyDelete(1), KittyDelete(2)}))
+	assert.NoError(t, out.Close())
+
+	data, err := os.ReadFile(file.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "frame"+KittyDelete(1)+KittyDelete(2), string(data))
+}
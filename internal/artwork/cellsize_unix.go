@@ -0,0 +1,18 @@
+//go:build !windows
+
+package artwork
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// CellSize is the size of a terminal cell in pixels, guessed when the terminal does not report it
+func CellSize() (width, height int) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 || ws.Xpixel == 0 || ws.Ypixel == 0 {
+		return defaultCellWidth, defaultCellHeight
+	}
+	return int(ws.Xpixel) / int(ws.Col), int(ws.Ypixel) / int(ws.Row)
+}
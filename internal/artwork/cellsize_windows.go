@@ -0,0 +1,8 @@
+//go:build windows
+
+package artwork
+
+// CellSize is the size of a terminal cell in pixels, the windows console does not report it
+func CellSize() (width, height int) {
+	return defaultCellWidth, defaultCellHeight
+}
@@ -0,0 +1,46 @@
+package artwork
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Output is the terminal the program draws its frames to, the images uploaded through it are never split by a frame
+type Output struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewOutput(file *os.File) *Output {
+	return &Output{file: file}
+}
+
+// Write writes a frame of the program
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Write(p)
+}
+
+// WriteAll writes the escape sequences one after another, no frame is written between them
+func (o *Output) WriteAll(sequences []string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := io.WriteString(o.file, strings.Join(sequences, ""))
+	return err
+}
+
+// Read, Close and Fd let the program see the terminal behind the output, it reads its size and sets its mode from it
+func (o *Output) Read(p []byte) (int, error) {
+	return o.file.Read(p)
+}
+
+func (o *Output) Close() error {
+	return o.file.Close()
+}
+
+func (o *Output) Fd() uintptr {
+	return o.file.Fd()
+}
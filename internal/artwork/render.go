@@ -0,0 +1,186 @@
+package artwork
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// the cell size of most terminals at their default font size
+const (
+	defaultCellWidth  = 10
+	defaultCellHeight = 20
+)
+
+// HalfBlocks draws img in cols by rows cells, the foreground of "▀" is the upper pixel and the background the lower one
+func HalfBlocks(img image.Image, cols, rows int) string {
+	pixels := Resize(img, cols, rows*2)
+	lines := make([]string, rows)
+	for y := range rows {
+		var b strings.Builder
+		for x := range cols {
+			b.WriteString(lipgloss.NewStyle().
+				Foreground(hexColor(pixels.RGBAAt(x, y*2))).
+				Background(hexColor(pixels.RGBAAt(x, y*2+1))).
+				Render("▀"))
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func hexColor(c color.RGBA) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+}
+
+// SixelImage encodes img as a sixel image of width by height pixels, the colours are reduced to a 6x6x6 cube
+func SixelImage(img image.Image, width, height int) string {
+	pixels := Resize(img, width, height)
+	index := func(x, y int) int {
+		p := pixels.RGBAAt(x, y)
+		return (int(p.R)+25)/51*36 + (int(p.G)+25)/51*6 + (int(p.B)+25)/51
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1bPq")
+	fmt.Fprintf(&b, "\"1;1;%d;%d", width, height)
+	used := map[int]bool{}
+	for y := range height {
+		for x := range width {
+			used[index(x, y)] = true
+		}
+	}
+	for i := range 216 {
+		if used[i] {
+			fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+		}
+	}
+	for band := 0; band < height; band += 6 {
+		colors := map[int]bool{}
+		for y := band; y < min(band+6, height); y++ {
+			for x := range width {
+				colors[index(x, y)] = true
+			}
+		}
+		var order []int
+		for c := range colors {
+			order = append(order, c)
+		}
+		sort.Ints(order)
+		for n, c := range order {
+			if n > 0 {
+				// back to the start of the band to draw the next colour over it
+				b.WriteByte('$')
+			}
+			fmt.Fprintf(&b, "#%d", c)
+			var run byte
+			count := 0
+			flush := func() {
+				switch {
+				case count > 3:
+					fmt.Fprintf(&b, "!%d%c", count, run)
+				case count > 0:
+					b.WriteString(strings.Repeat(string(run), count))
+				}
+			}
+			for x := range width {
+				var bits byte
+				for dy := range 6 {
+					if y := band + dy; y < height && index(x, y) == c {
+						bits |= 1 << dy
+					}
+				}
+				char := 63 + bits
+				if char != run {
+					flush()
+					run, count = char, 0
+				}
+				count++
+			}
+			flush()
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// SixelCells draws img in cols by rows cells, the cursor is saved and restored around the image so the text after it
+// on the line stays in place, the cells under the image are left blank
+func SixelCells(img image.Image, cols, rows, cellWidth, cellHeight int) string {
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", cols)
+	}
+	lines[0] = "\x1b7" + SixelImage(img, cols*cellWidth, rows*cellHeight) + "\x1b8" + lines[0]
+	return strings.Join(lines, "\n")
+}
+
+// kittyDiacritics encode the row and the column of an image cell drawn with kitty's unicode placeholders
+var kittyDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F, 0x0346, 0x034A,
+	0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357, 0x035B, 0x0363, 0x0364, 0x0365,
+	0x0366, 0x0367, 0x0368, 0x0369, 0x036A, 0x036B, 0x036C, 0x036D, 0x036E, 0x036F,
+	0x0483, 0x0484, 0x0485, 0x0486, 0x0487, 0x0592, 0x0593, 0x0594, 0x0595, 0x0597,
+}
+
+// MaxKittyCells is the widest and tallest image kittyDiacritics can address
+var MaxKittyCells = len(kittyDiacritics)
+
+const kittyPlaceholder = '\U0010EEEE'
+
+// KittyTransmit are the escape sequences that upload img as a png with the id, and create a virtual placement
+// of cols by rows cells that KittyPlaceholders draws, every chunk has to be written whole
+func KittyTransmit(id uint32, img image.Image, cols, rows, cellWidth, cellHeight int) ([]string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, Resize(img, cols*cellWidth, rows*cellHeight)); err != nil {
+		return nil, err
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+	const chunkSize = 4096
+	var chunks []string
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+		more := 0
+		if end < len(data) {
+			more = 1
+		}
+		if start == 0 {
+			chunks = append(chunks, fmt.Sprintf("\x1b_Ga=T,U=1,q=2,f=100,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, cols, rows, more, data[start:end]))
+			continue
+		}
+		chunks = append(chunks, fmt.Sprintf("\x1b_Gm=%d;%s\x1b\\", more, data[start:end]))
+	}
+	return chunks, nil
+}
+
+// KittyDelete frees the image uploaded with the id in the terminal
+func KittyDelete(id uint32) string {
+	return fmt.Sprintf("\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", id)
+}
+
+// KittyPlaceholders draws the image uploaded with the id, the foreground colour of the cells carries the id
+func KittyPlaceholders(id uint32, cols, rows int) string {
+	cols, rows = min(cols, MaxKittyCells), min(rows, MaxKittyCells)
+	fg := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", id>>16&0xff, id>>8&0xff, id&0xff)
+	lines := make([]string, rows)
+	for y := range rows {
+		var b strings.Builder
+		b.WriteString(fg)
+		for x := range cols {
+			b.WriteRune(kittyPlaceholder)
+			b.WriteRune(kittyDiacritics[y])
+			b.WriteRune(kittyDiacritics[x])
+		}
+		b.WriteString("\x1b[39m")
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,267 @@
+package cache
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"sync"
+	"time"
+
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// how long a response is served without asking the backend again, a stale response is still served right away while it is refreshed in the background
+var ttls = map[string]time.Duration{
+	"GetHomePage":        30 * time.Minute,
+	"GetExplore":         time.Hour,
+	"GetCharts":          6 * time.Hour,
+	"GetMoodCategories":  24 * time.Hour,
+	"GetMoodPlaylists":   6 * time.Hour,
+	"GetUserPlaylists":   10 * time.Minute,
+	"GetUserSavedAlbums": 10 * time.Minute,
+	"GetFollowedArtists": 10 * time.Minute,
+	"GetUserSavedTracks": 10 * time.Minute,
+	"GetPlaylistItems":   10 * time.Minute,
+	"GetAlbumTracks":     24 * time.Hour,
+	"GetArtist":          6 * time.Hour,
+}
+
+const refreshTimeout = 30 * time.Second
+
+// Client serves the metadata rpcs from the Store and passes every other rpc to the backend,
+// writes mark the responses they change as invalidated
+type Client struct {
+	musicpb.MusicServiceClient
+	store *Store
+	now   func() time.Time
+	// a types.CacheRefreshedMsg is sent for every background refresh that finished, nothing is sent when it is full
+	Refreshed chan types.CacheRefreshedMsg
+
+	mu       sync.Mutex
+	inFlight map[string]bool
+}
+
+func NewClient(client musicpb.MusicServiceClient, store *Store) *Client {
+	return &Client{
+		MusicServiceClient: client,
+		store:              store,
+		now:                time.Now,
+		Refreshed:          make(chan types.CacheRefreshedMsg, 10),
+		inFlight:           map[string]bool{},
+	}
+}
+
+// cacheKey is the rpc and the request in the wire format, which is the same for equal requests when it is deterministic
+func cacheKey(method string, req proto.Message) (string, error) {
+	args, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	return method + " " + base64.StdEncoding.EncodeToString(args), nil
+}
+
+func decode[Resp proto.Message](entry Entry) (Resp, bool) {
+	var resp Resp
+	resp = resp.ProtoReflect().New().Interface().(Resp)
+	if err := protojson.Unmarshal(entry.Data, resp); err != nil {
+		slog.Error("failed to decode cached response", "method", entry.Method, "err", err)
+		return resp, false
+	}
+	return resp, true
+}
+
+func cached[Req, Resp proto.Message](c *Client, ctx context.Context, method string, req Req, call func(context.Context, Req, ...grpc.CallOption) (Resp, error), opts []grpc.CallOption) (Resp, error) {
+	key, err := cacheKey(method, req)
+	if err != nil {
+		return call(ctx, req, opts...)
+	}
+	entry, ok := c.store.Get(key)
+	if ok && !entry.Invalidated {
+		if resp, ok := decode[Resp](entry); ok {
+			if c.now().Sub(entry.StoredAt) >= ttls[method] {
+				refresh(c, key, method, req, func(ctx context.Context) (Resp, error) {
+					return call(ctx, req, opts...)
+				})
+			}
+			return resp, nil
+		}
+	}
+
+	resp, err := call(ctx, req, opts...)
+	if err != nil {
+		if ok {
+			if stale, decoded := decode[Resp](entry); decoded {
+				slog.Warn("serving cached response, the backend is unreachable", "method", method, "err", err)
+				return stale, nil
+			}
+		}
+		return resp, err
+	}
+	c.put(key, method, resp)
+	return resp, nil
+}
+
+func (c *Client) put(key, method string, resp proto.Message) {
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		slog.Error("failed to encode response for the cache", "method", method, "err", err)
+		return
+	}
+	c.store.Put(key, method, data, c.now())
+}
+
+func refresh[Req, Resp proto.Message](c *Client, key, method string, req Req, call func(context.Context) (Resp, error)) {
+	c.mu.Lock()
+	if c.inFlight[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.inFlight[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.inFlight, key)
+			c.mu.Unlock()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		resp, err := call(ctx)
+		if err != nil {
+			slog.Warn("failed to refresh cached response", "method", method, "err", err)
+			return
+		}
+		c.put(key, method, resp)
+		select {
+		case c.Refreshed <- types.CacheRefreshedMsg{Method: method, Request: req}:
+		default:
+		}
+	}()
+}
+
+func (c *Client) invalidate(err error, methods ...string) {
+	if err != nil {
+		return
+	}
+	c.store.Invalidate(methods...)
+}
+
+func (c *Client) HasTrack(videoID string) bool {
+	return c.store.HasTrack(videoID)
+}
+
+func (c *Client) GetHomePage(ctx context.Context, in *musicpb.GetHomePageRequest, opts ...grpc.CallOption) (*musicpb.GetHomePageResponse, error) {
+	return cached(c, ctx, "GetHomePage", in, c.MusicServiceClient.GetHomePage, opts)
+}
+
+func (c *Client) GetExplore(ctx context.Context, in *musicpb.GetExploreRequest, opts ...grpc.CallOption) (*musicpb.GetExploreResponse, error) {
+	return cached(c, ctx, "GetExplore", in, c.MusicServiceClient.GetExplore, opts)
+}
+
+func (c *Client) GetCharts(ctx context.Context, in *musicpb.GetChartsRequest, opts ...grpc.CallOption) (*musicpb.GetChartsResponse, error) {
+	return cached(c, ctx, "GetCharts", in, c.MusicServiceClient.GetCharts, opts)
+}
+
+func (c *Client) GetMoodCategories(ctx context.Context, in *musicpb.GetMoodCategoriesRequest, opts ...grpc.CallOption) (*musicpb.GetMoodCategoriesResponse, error) {
+	return cached(c, ctx, "GetMoodCategories", in, c.MusicServiceClient.GetMoodCategories, opts)
+}
+
+func (c *Client) GetMoodPlaylists(ctx context.Context, in *musicpb.GetMoodPlaylistsRequest, opts ...grpc.CallOption) (*musicpb.GetMoodPlaylistsResponse, error) {
+	return cached(c, ctx, "GetMoodPlaylists", in, c.MusicServiceClient.GetMoodPlaylists, opts)
+}
+
+func (c *Client) GetUserPlaylists(ctx context.Context, in *musicpb.GetUserPlaylistsRequest, opts ...grpc.CallOption) (*musicpb.GetUserPlaylistsResponse, error) {
+	return cached(c, ctx, "GetUserPlaylists", in, c.MusicServiceClient.GetUserPlaylists, opts)
+}
+
+func (c *Client) GetUserSavedAlbums(ctx context.Context, in *musicpb.GetUserSavedAlbumsRequest, opts ...grpc.CallOption) (*musicpb.GetUserSavedAlbumsResponse, error) {
+	return cached(c, ctx, "GetUserSavedAlbums", in, c.MusicServiceClient.GetUserSavedAlbums, opts)
+}
+
+func (c *Client) GetFollowedArtists(ctx context.Context, in *musicpb.GetFollowedArtistsRequest, opts ...grpc.CallOption) (*musicpb.GetFollowedArtistsResponse, error) {
+	return cached(c, ctx, "GetFollowedArtists", in, c.MusicServiceClient.GetFollowedArtists, opts)
+}
+
+func (c *Client) GetUserSavedTracks(ctx context.Context, in *musicpb.GetUserSavedTracksRequest, opts ...grpc.CallOption) (*musicpb.GetUserSavedTracksResponse, error) {
+	return cached(c, ctx, "GetUserSavedTracks", in, c.MusicServiceClient.GetUserSavedTracks, opts)
+}
+
+func (c *Client) GetPlaylistItems(ctx context.Context, in *musicpb.GetPlaylistItemsRequest, opts ...grpc.CallOption) (*musicpb.GetPlaylistItemsResponse, error) {
+	return cached(c, ctx, "GetPlaylistItems", in, c.MusicServiceClient.GetPlaylistItems, opts)
+}
+
+func (c *Client) GetAlbumTracks(ctx context.Context, in *musicpb.GetAlbumTracksRequest, opts ...grpc.CallOption) (*musicpb.GetAlbumTracksResponse, error) {
+	return cached(c, ctx, "GetAlbumTracks", in, c.MusicServiceClient.GetAlbumTracks, opts)
+}
+
+func (c *Client) GetArtist(ctx context.Context, in *musicpb.GetArtistRequest, opts ...grpc.CallOption) (*musicpb.GetArtistResponse, error) {
+	return cached(c, ctx, "GetArtist", in, c.MusicServiceClient.GetArtist, opts)
+}
+
+// the liked state is part of every track list
+var likedTrackMethods = []string{"GetUserSavedTracks", "GetPlaylistItems", "GetAlbumTracks"}
+
+func (c *Client) LikeSong(ctx context.Context, in *musicpb.LikeSongRequest, opts ...grpc.CallOption) (*musicpb.LikeSongResponse, error) {
+	resp, err := c.MusicServiceClient.LikeSong(ctx, in, opts...)
+	c.invalidate(err, likedTrackMethods...)
+	return resp, err
+}
+
+func (c *Client) UnlikeSong(ctx context.Context, in *musicpb.UnlikeSongRequest, opts ...grpc.CallOption) (*musicpb.UnlikeSongResponse, error) {
+	resp, err := c.MusicServiceClient.UnlikeSong(ctx, in, opts...)
+	c.invalidate(err, likedTrackMethods...)
+	return resp, err
+}
+
+func (c *Client) SaveRemoveTrack(ctx context.Context, in *musicpb.SaveRemoveTrackRequest, opts ...grpc.CallOption) (*musicpb.SaveRemoveTrackResponse, error) {
+	resp, err := c.MusicServiceClient.SaveRemoveTrack(ctx, in, opts...)
+	c.invalidate(err, likedTrackMethods...)
+	return resp, err
+}
+
+func (c *Client) CreatePlaylist(ctx context.Context, in *musicpb.CreatePlaylistRequest, opts ...grpc.CallOption) (*musicpb.CreatePlaylistResponse, error) {
+	resp, err := c.MusicServiceClient.CreatePlaylist(ctx, in, opts...)
+	c.invalidate(err, "GetUserPlaylists")
+	return resp, err
+}
+
+func (c *Client) AddPlaylistItems(ctx context.Context, in *musicpb.AddPlaylistItemsRequest, opts ...grpc.CallOption) (*musicpb.AddPlaylistItemsResponse, error) {
+	resp, err := c.MusicServiceClient.AddPlaylistItems(ctx, in, opts...)
+	c.invalidate(err, "GetUserPlaylists", "GetPlaylistItems")
+	return resp, err
+}
+
+func (c *Client) RemovePlaylistItems(ctx context.Context, in *musicpb.RemovePlaylistItemsRequest, opts ...grpc.CallOption) (*musicpb.RemovePlaylistItemsResponse, error) {
+	resp, err := c.MusicServiceClient.RemovePlaylistItems(ctx, in, opts...)
+	c.invalidate(err, "GetUserPlaylists", "GetPlaylistItems")
+	return resp, err
+}
+
+func (c *Client) EditPlaylist(ctx context.Context, in *musicpb.EditPlaylistRequest, opts ...grpc.CallOption) (*musicpb.EditPlaylistResponse, error) {
+	resp, err := c.MusicServiceClient.EditPlaylist(ctx, in, opts...)
+	c.invalidate(err, "GetUserPlaylists", "GetPlaylistItems")
+	return resp, err
+}
+
+func (c *Client) DeletePlaylist(ctx context.Context, in *musicpb.DeletePlaylistRequest, opts ...grpc.CallOption) (*musicpb.DeletePlaylistResponse, error) {
+	resp, err := c.MusicServiceClient.DeletePlaylist(ctx, in, opts...)
+	c.invalidate(err, "GetUserPlaylists", "GetPlaylistItems")
+	return resp, err
+}
+
+func (c *Client) FollowArtist(ctx context.Context, in *musicpb.FollowArtistRequest, opts ...grpc.CallOption) (*musicpb.FollowArtistResponse, error) {
+	resp, err := c.MusicServiceClient.FollowArtist(ctx, in, opts...)
+	c.invalidate(err, "GetFollowedArtists", "GetArtist")
+	return resp, err
+}
+
+func (c *Client) UnfollowArtist(ctx context.Context, in *musicpb.UnfollowArtistRequest, opts ...grpc.CallOption) (*musicpb.UnfollowArtistResponse, error) {
+	resp, err := c.MusicServiceClient.UnfollowArtist(ctx, in, opts...)
+	c.invalidate(err, "GetFollowedArtists", "GetArtist")
+	return resp, err
+}
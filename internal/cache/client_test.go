@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeBackend struct {
+	musicpb.MusicServiceClient
+	calls int
+	title string
+	err   error
+}
+
+func (f *fakeBackend) GetPlaylistItems(ctx context.Context, in *musicpb.GetPlaylistItemsRequest, opts ...grpc.CallOption) (*musicpb.GetPlaylistItemsResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &musicpb.GetPlaylistItemsResponse{Title: f.title}, nil
+}
+
+func (f *fakeBackend) EditPlaylist(ctx context.Context, in *musicpb.EditPlaylistRequest, opts ...grpc.CallOption) (*musicpb.EditPlaylistResponse, error) {
+	return &musicpb.EditPlaylistResponse{}, f.err
+}
+
+func newTestClient(t *testing.T, backend *fakeBackend) *Client {
+	return NewClient(backend, newTestStore(t))
+}
+
+func TestClient_ServesFreshEntriesFromTheCache(t *testing.T) {
+	backend := &fakeBackend{title: "first"}
+	client := newTestClient(t, backend)
+	req := &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL1"}
+
+	_, err := client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+	backend.title = "second"
+	resp, err := client.GetPlaylistItems(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "first", resp.Title)
+	assert.Equal(t, 1, backend.calls)
+}
+
+func TestClient_RefreshesStaleEntriesInTheBackground(t *testing.T) {
+	backend := &fakeBackend{title: "first"}
+	client := newTestClient(t, backend)
+	req := &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL1"}
+	_, err := client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+
+	client.now = func() time.Time { return time.Now().Add(time.Hour) }
+	backend.title = "second"
+	resp, err := client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", resp.Title)
+
+	select {
+	case msg := <-client.Refreshed:
+		assert.Equal(t, "GetPlaylistItems", msg.Method)
+		assert.Equal(t, req, msg.Request)
+	case <-time.After(time.Second):
+		t.Fatal("the stale entry was not refreshed")
+	}
+	client.now = time.Now
+	resp, err = client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", resp.Title)
+}
+
+func TestClient_ServesStaleEntriesWhenTheBackendIsDown(t *testing.T) {
+	backend := &fakeBackend{title: "first"}
+	client := newTestClient(t, backend)
+	req := &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL1"}
+	_, err := client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+
+	_, err = client.EditPlaylist(context.Background(), &musicpb.EditPlaylistRequest{PlaylistId: "PL1"})
+	assert.NoError(t, err)
+	backend.err = errors.New("unavailable")
+	resp, err := client.GetPlaylistItems(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "first", resp.Title)
+	assert.Equal(t, 2, backend.calls)
+}
+
+func TestClient_WritesInvalidateTheResponsesTheyChange(t *testing.T) {
+	backend := &fakeBackend{title: "first"}
+	client := newTestClient(t, backend)
+	req := &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL1"}
+	_, err := client.GetPlaylistItems(context.Background(), req)
+	assert.NoError(t, err)
+
+	_, err = client.EditPlaylist(context.Background(), &musicpb.EditPlaylistRequest{PlaylistId: "PL1", Title: "renamed"})
+	assert.NoError(t, err)
+	backend.title = "renamed"
+	resp, err := client.GetPlaylistItems(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", resp.Title)
+	assert.Equal(t, 2, backend.calls)
+}
+
+func TestCacheKey_IsTheSameForEqualRequests(t *testing.T) {
+	first, err := cacheKey("GetPlaylistItems", &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL1", Limit: 50})
+	assert.NoError(t, err)
+	second, err := cacheKey("GetPlaylistItems", &musicpb.GetPlaylistItemsRequest{Limit: 50, PlaylistId: "PL1"})
+	assert.NoError(t, err)
+	other, err := cacheKey("GetPlaylistItems", &musicpb.GetPlaylistItemsRequest{PlaylistId: "PL2", Limit: 50})
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/jsonfile"
+)
+
+// MaxEntries keeps the cache file from growing forever, the entries stored the longest ago are dropped first
+const MaxEntries = 500
+
+// SaveDelay is how long a change waits before the file is written, the changes made meanwhile are written with it
+const SaveDelay = 2 * time.Second
+
+type Entry struct {
+	Method   string          `json:"method"`
+	Data     json.RawMessage `json:"data"`
+	StoredAt time.Time       `json:"storedAt"`
+	// set after a write that changed the data, the entry is only served when the backend can not be reached
+	Invalidated bool `json:"invalidated"`
+}
+
+// Store keeps the responses of the backend on disk keyed by the rpc and its arguments
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]Entry
+	// the write scheduled by a change, nil while the file is up to date
+	pending *time.Timer
+	// held while a write is marshalled and written, so two writes do not race on the file
+	writeMu sync.Mutex
+}
+
+func DefaultPath(goos string) string {
+	return filepath.Join(config.GetCacheDir(goos), "metadata.json")
+}
+
+// New loads the cache stored at path
+func New(path string) *Store {
+	entries := jsonfile.Load[map[string]Entry](path, "metadata cache")
+	if entries == nil {
+		entries = map[string]Entry{}
+	}
+	return &Store{path: path, entries: entries}
+}
+
+func (s *Store) Get(key string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+// Put stores the response, the file is written in the background after SaveDelay
+func (s *Store) Put(key, method string, data []byte, storedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = Entry{Method: method, Data: data, StoredAt: storedAt}
+	s.evict()
+	s.scheduleSave()
+}
+
+// Invalidate marks every entry of the given rpcs as changed by a write
+func (s *Store) Invalidate(methods ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := false
+	for key, entry := range s.entries {
+		for _, method := range methods {
+			if entry.Method == method && !entry.Invalidated {
+				entry.Invalidated = true
+				s.entries[key] = entry
+				changed = true
+			}
+		}
+	}
+	if changed {
+		s.scheduleSave()
+	}
+}
+
+// HasTrack reports whether a cached response lists the track, so it can be browsed while offline
+func (s *Store) HasTrack(videoID string) bool {
+	if videoID == "" {
+		return false
+	}
+	quoted := []byte(strconv.Quote(videoID))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if bytes.Contains(entry.Data, quoted) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+func (s *Store) evict() {
+	if len(s.entries) <= MaxEntries {
+		return
+	}
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.entries[keys[i]].StoredAt.Before(s.entries[keys[j]].StoredAt)
+	})
+	for _, key := range keys[:len(keys)-MaxEntries] {
+		delete(s.entries, key)
+	}
+}
+
+// Flush writes the changes that wait for SaveDelay right away, call it before exiting
+func (s *Store) Flush() error {
+	// the data is marshalled under writeMu too, so an older copy never overwrites a newer one
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.mu.Lock()
+	if s.pending == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	s.pending.Stop()
+	s.pending = nil
+	data, err := json.Marshal(s.entries)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return jsonfile.Write(s.path, data)
+}
+
+func (s *Store) scheduleSave() {
+	if s.pending != nil {
+		return
+	}
+	s.pending = time.AfterFunc(SaveDelay, func() {
+		if err := s.Flush(); err != nil {
+			slog.Error("failed to write the metadata cache", "err", err)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestStore writes the pending changes before the temporary directory is removed
+func newTestStore(t *testing.T) *Store {
+	store := New(filepath.Join(t.TempDir(), "metadata.json"))
+	t.Cleanup(func() { assert.NoError(t, store.Flush()) })
+	return store
+}
+
+func TestStore_PutPersistsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "metadata.json")
+	storedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := New(path)
+	store.Put("GetHomePage {}", "GetHomePage", []byte(`{"sections":[]}`), storedAt)
+	_, ok := New(path).Get("GetHomePage {}")
+	assert.False(t, ok, "written before SaveDelay")
+	assert.NoError(t, store.Flush())
+
+	entry, ok := New(path).Get("GetHomePage {}")
+	assert.True(t, ok)
+	assert.Equal(t, "GetHomePage", entry.Method)
+	assert.JSONEq(t, `{"sections":[]}`, string(entry.Data))
+	assert.True(t, storedAt.Equal(entry.StoredAt))
+}
+
+func TestStore_InvalidateMarksOnlyTheGivenMethods(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	store.Put("a", "GetPlaylistItems", []byte(`{}`), now)
+	store.Put("b", "GetHomePage", []byte(`{}`), now)
+
+	store.Invalidate("GetPlaylistItems")
+
+	entry, _ := store.Get("a")
+	assert.True(t, entry.Invalidated)
+	entry, _ = store.Get("b")
+	assert.False(t, entry.Invalidated)
+}
+
+func TestStore_EvictsTheOldestEntries(t *testing.T) {
+	store := newTestStore(t)
+	start := time.Now()
+	for i := 0; i <= MaxEntries; i++ {
+		store.Put(fmt.Sprintf("key %d", i), "GetArtist", []byte(`{}`), start.Add(time.Duration(i)*time.Second))
+	}
+
+	assert.Equal(t, MaxEntries, store.Len())
+	_, ok := store.Get("key 0")
+	assert.False(t, ok)
+	_, ok = store.Get(fmt.Sprintf("key %d", MaxEntries))
+	assert.True(t, ok)
+}
+
+func TestStore_HasTrack(t *testing.T) {
+	store := newTestStore(t)
+	store.Put("a", "GetPlaylistItems", []byte(`{"tracks":[{"video_id":"dQw4w9WgXcQ"}]}`), time.Now())
+
+	assert.True(t, store.HasTrack("dQw4w9WgXcQ"))
+	assert.False(t, store.HasTrack("dQw4w9WgXc"))
+	assert.False(t, store.HasTrack(""))
+}
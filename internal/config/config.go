@@ -18,7 +18,38 @@ type Config struct {
 	CacheDir      *string    `json:"cache-dir"`
 	YtDlpArgs     *YtDlpArgs `json:"yt-dlp-args"`
 	HeadlessMode  bool       `json:"headless-mode"`
-	SkipOnNoMatch bool       `json:"skip-on-no-match"`
+	// when the queue reaches a track the content filter rejects, skip to the next one instead of stopping
+	SkipOnNoMatch bool `json:"skip-on-no-match"`
+	// do not add a track to the queue when the same song is already there
+	PreventDuplicates bool `json:"prevent-duplicates"`
+	// ISO 3166-1 alpha-2 code of the country the charts are shown for, global charts when empty
+	ChartsCountry string `json:"charts-country"`
+	// always ask the backend instead of serving home, library and playlists from the metadata cache
+	DisableMetadataCache bool `json:"disable-metadata-cache"`
+	// "hide" leaves explicit tracks out of every list, "skip" keeps them listed but never plays them
+	ExplicitFilter string `json:"explicit-filter"`
+	// directory of .lrc files that override the lyrics of youtube music, "lyrics" in the config directory when empty
+	LyricsDir string `json:"lyrics-dir"`
+	// replaces the keys of an action, e.g. {"next-track": ["n", "ctrl+n"]}, an empty list unbinds it
+	Keymap map[string][]string `json:"keymap"`
+	// dark, light, high-contrast or the name of a theme file in the themes directory of the config dir
+	Theme string `json:"theme"`
+	// colours and borders set on top of the theme, in the shape of a theme file
+	ThemeOverrides json.RawMessage `json:"theme-overrides"`
+	// how cover art is drawn: kitty, sixel, blocks or off, detected from the terminal when empty or "auto"
+	AlbumArt string `json:"album-art"`
+	// how the width is shared between the panes and when the sidebar and the queue fold away
+	Layout Layout `json:"layout"`
+}
+
+// Layout is the "layout" section of config.json, a zero value keeps the default
+type Layout struct {
+	// percent of the width the sidebar and the queue take while they are docked
+	SidebarWidth int `json:"sidebar-width"`
+	QueueWidth   int `json:"queue-width"`
+	// terminals narrower than these many columns fold the pane away, it is then only shown as an overlay
+	SidebarBreakpoint int `json:"sidebar-breakpoint"`
+	QueueBreakpoint   int `json:"queue-breakpoint"`
 }
 
 var userConfigDir = os.UserConfigDir
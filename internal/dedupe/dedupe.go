@@ -0,0 +1,103 @@
+package dedupe
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// bracketed parts of a title that only describe the upload, not the song, e.g. "(Official Video)" or "[Lyrics]"
+var uploadNoise = regexp.MustCompile(`(?i)\s*[(\[][^)\]]*\b(official|video|audio|lyrics?|visuali[sz]er|hd|hq|4k|mv|m/v|explicit|clean)\b[^)\]]*[)\]]`)
+
+var featuring = regexp.MustCompile(`(?i)\s+[(\[]?\s*(feat\.?|ft\.?|featuring)\s.*$`)
+
+// Normalize lowercases s and drops everything but letters and digits so small differences in spelling do not matter
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SongKey identifies a song independently of the upload, it is empty when the track has no artist or title
+func SongKey(track types.Track) string {
+	if len(track.Artists) == 0 {
+		return ""
+	}
+	artist := strings.TrimSuffix(track.Artists[0].Name, " - Topic")
+	artist = strings.TrimSuffix(artist, "VEVO")
+	title := uploadNoise.ReplaceAllString(track.Name, "")
+	title = featuring.ReplaceAllString(title, "")
+
+	artist, title = Normalize(artist), Normalize(title)
+	if artist == "" || title == "" {
+		return ""
+	}
+	return artist + "|" + title
+}
+
+// Set remembers the songs seen so far, matching on the video ID and on the normalized artist and title
+type Set struct {
+	ids  map[string]struct{}
+	keys map[string]struct{}
+}
+
+func NewSet() *Set {
+	return &Set{
+		ids:  map[string]struct{}{},
+		keys: map[string]struct{}{},
+	}
+}
+
+func (s *Set) Contains(track types.Track) bool {
+	if _, ok := s.ids[track.ID]; ok && track.ID != "" {
+		return true
+	}
+	key := SongKey(track)
+	if key == "" {
+		return false
+	}
+	_, ok := s.keys[key]
+	return ok
+}
+
+// Add adds the track and reports whether it was new
+func (s *Set) Add(track types.Track) bool {
+	if s.Contains(track) {
+		return false
+	}
+	if track.ID != "" {
+		s.ids[track.ID] = struct{}{}
+	}
+	if key := SongKey(track); key != "" {
+		s.keys[key] = struct{}{}
+	}
+	return true
+}
+
+// Unique returns the indexes of the tracks to keep, the first copy of every song wins unless keepID is the ID of a later copy,
+// which is used to keep the track that is currently playing
+func Unique(tracks []types.Track, keepID string) []int {
+	set := NewSet()
+	keepIndex := -1
+	for i, track := range tracks {
+		if keepID != "" && track.ID == keepID {
+			set.Add(track)
+			keepIndex = i
+			break
+		}
+	}
+
+	var indexes []int
+	for i, track := range tracks {
+		if i == keepIndex || set.Add(track) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,44 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func track(id, artist, title string) types.Track {
+	return types.Track{ID: id, Name: title, Artists: []types.Artist{{Name: artist}}}
+}
+
+func TestSongKey(t *testing.T) {
+	want := SongKey(track("a", "Daft Punk", "Get Lucky"))
+	assert.Equal(t, "daftpunk|getlucky", want)
+	assert.Equal(t, want, SongKey(track("b", "Daft Punk - Topic", "Get Lucky (Official Audio)")))
+	assert.Equal(t, want, SongKey(track("c", "DAFT PUNK", "Get Lucky [Official Music Video]")))
+	assert.Equal(t, want, SongKey(track("d", "Daft Punk", "Get Lucky (feat. Pharrell Williams)")))
+	assert.NotEqual(t, want, SongKey(track("e", "Daft Punk", "Get Lucky (Radio Edit)")))
+	assert.Equal(t, "", SongKey(types.Track{ID: "f", Name: "Untitled"}))
+}
+
+func TestSet(t *testing.T) {
+	set := NewSet()
+	assert.True(t, set.Add(track("a", "Daft Punk", "Get Lucky")))
+	assert.False(t, set.Add(track("a", "Someone", "Else")))
+	assert.False(t, set.Add(track("c", "Daft Punk - Topic", "Get Lucky")))
+	assert.True(t, set.Add(track("d", "Daft Punk", "Around the World")))
+	assert.True(t, set.Add(types.Track{ID: "e"}))
+	assert.True(t, set.Add(types.Track{ID: "f"}))
+}
+
+func TestUnique(t *testing.T) {
+	tracks := []types.Track{
+		track("a", "Daft Punk", "Get Lucky"),
+		track("b", "Daft Punk", "Around the World"),
+		track("c", "Daft Punk - Topic", "Get Lucky"),
+		track("b", "Daft Punk", "Around the World"),
+	}
+	assert.Equal(t, []int{0, 1}, Unique(tracks, ""))
+	assert.Equal(t, []int{1, 2}, Unique(tracks, "c"))
+	assert.Equal(t, []int{0, 1}, Unique(tracks, "missing"))
+}
@@ -0,0 +1,209 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/jsonfile"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// ExplicitMode is what happens to explicit tracks, set with "explicit-filter" in config.json
+type ExplicitMode string
+
+const (
+	ExplicitShow ExplicitMode = ""
+	// explicit tracks are left out of every list
+	ExplicitHide ExplicitMode = "hide"
+	// explicit tracks are listed but never played when the queue moves on by itself
+	ExplicitSkip ExplicitMode = "skip"
+)
+
+// ParseExplicitMode falls back to showing explicit tracks when the value is not a known mode
+func ParseExplicitMode(value string) (ExplicitMode, error) {
+	switch mode := ExplicitMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ExplicitShow, ExplicitHide, ExplicitSkip:
+		return mode, nil
+	case "show":
+		return ExplicitShow, nil
+	}
+	return ExplicitShow, fmt.Errorf("unknown explicit-filter %q, expected show, hide or skip", value)
+}
+
+type BlockedArtist struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type BlockedTrack struct {
+	VideoID string `json:"videoId"`
+	Name    string `json:"name"`
+}
+
+type Blocklist struct {
+	Artists []BlockedArtist `json:"artists"`
+	Tracks  []BlockedTrack  `json:"tracks"`
+}
+
+// Filter decides which tracks are listed and played, a nil Filter lets everything through
+type Filter struct {
+	mu        sync.RWMutex
+	path      string
+	explicit  ExplicitMode
+	blocklist Blocklist
+}
+
+func DefaultPath(goos string) string {
+	return filepath.Join(config.GetConfigDir(goos), "blocklist.json")
+}
+
+// New loads the blocklist stored at path
+func New(path string, explicit ExplicitMode) *Filter {
+	return &Filter{path: path, explicit: explicit, blocklist: jsonfile.Load[Blocklist](path, "blocklist")}
+}
+
+func (f *Filter) Explicit() ExplicitMode {
+	if f == nil {
+		return ExplicitShow
+	}
+	return f.explicit
+}
+
+// Blocklist returns a copy of the blocked artists and tracks
+func (f *Filter) Blocklist() Blocklist {
+	if f == nil {
+		return Blocklist{}
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return Blocklist{
+		Artists: slices.Clone(f.blocklist.Artists),
+		Tracks:  slices.Clone(f.blocklist.Tracks),
+	}
+}
+
+func (f *Filter) isArtistBlocked(artist types.Artist) bool {
+	for _, blocked := range f.blocklist.Artists {
+		if blocked.ID != "" && blocked.ID == artist.ID {
+			return true
+		}
+		// some tracks only carry the name of their artists
+		if blocked.Name != "" && strings.EqualFold(strings.TrimSpace(blocked.Name), strings.TrimSpace(artist.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) IsArtistBlocked(artist types.Artist) bool {
+	if f == nil {
+		return false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.isArtistBlocked(artist)
+}
+
+// IsBlocked reports whether the track or one of its artists is on the blocklist
+func (f *Filter) IsBlocked(track types.Track) bool {
+	if f == nil {
+		return false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, blocked := range f.blocklist.Tracks {
+		if blocked.VideoID == track.ID {
+			return true
+		}
+	}
+	for _, artist := range track.Artists {
+		if f.isArtistBlocked(artist) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hides reports whether the track is left out of lists
+func (f *Filter) Hides(track types.Track) bool {
+	if f == nil {
+		return false
+	}
+	return f.IsBlocked(track) || (track.Explicit && f.explicit == ExplicitHide)
+}
+
+// Plays reports whether the track may be played when the queue moves on by itself
+func (f *Filter) Plays(track types.Track) bool {
+	if f == nil {
+		return true
+	}
+	return !f.IsBlocked(track) && !(track.Explicit && f.explicit != ExplicitShow)
+}
+
+// Visible returns the items whose track is not hidden, track returns the track of an item
+func Visible[T any](f *Filter, items []T, track func(T) types.Track) []T {
+	if f == nil {
+		return items
+	}
+	visible := make([]T, 0, len(items))
+	for _, item := range items {
+		if !f.Hides(track(item)) {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
+
+func (f *Filter) BlockArtist(artist BlockedArtist) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.isArtistBlocked(types.Artist{ID: artist.ID, Name: artist.Name}) {
+		return nil
+	}
+	f.blocklist.Artists = append(f.blocklist.Artists, artist)
+	return f.save()
+}
+
+func (f *Filter) BlockTrack(track BlockedTrack) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, blocked := range f.blocklist.Tracks {
+		if blocked.VideoID == track.VideoID {
+			return nil
+		}
+	}
+	f.blocklist.Tracks = append(f.blocklist.Tracks, track)
+	return f.save()
+}
+
+func (f *Filter) UnblockArtist(artist BlockedArtist) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocklist.Artists = slices.DeleteFunc(f.blocklist.Artists, func(blocked BlockedArtist) bool {
+		return blocked == artist
+	})
+	return f.save()
+}
+
+func (f *Filter) UnblockTrack(videoID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocklist.Tracks = slices.DeleteFunc(f.blocklist.Tracks, func(blocked BlockedTrack) bool {
+		return blocked.VideoID == videoID
+	})
+	return f.save()
+}
+
+// save indents the file, it sits next to config.json and is read like it
+func (f *Filter) save() error {
+	data, err := json.MarshalIndent(f.blocklist, "", "  ")
+	if err != nil {
+		return err
+	}
+	return jsonfile.Write(f.path, data)
+}
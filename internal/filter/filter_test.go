@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func track(id, artist string, explicit bool) types.Track {
+	return types.Track{ID: id, Name: id, Artists: []types.Artist{{Name: artist}}, Explicit: explicit}
+}
+
+func TestFilter_NilLetsEverythingThrough(t *testing.T) {
+	var f *Filter
+	assert.False(t, f.Hides(track("a", "x", true)))
+	assert.True(t, f.Plays(track("a", "x", true)))
+}
+
+func TestFilter_ExplicitModes(t *testing.T) {
+	explicit := track("a", "x", true)
+	clean := track("b", "x", false)
+	path := filepath.Join(t.TempDir(), "blocklist.json")
+
+	show := New(path, ExplicitShow)
+	assert.False(t, show.Hides(explicit))
+	assert.True(t, show.Plays(explicit))
+
+	hide := New(path, ExplicitHide)
+	assert.True(t, hide.Hides(explicit))
+	assert.False(t, hide.Plays(explicit))
+	assert.False(t, hide.Hides(clean))
+
+	skip := New(path, ExplicitSkip)
+	assert.False(t, skip.Hides(explicit))
+	assert.False(t, skip.Plays(explicit))
+	assert.True(t, skip.Plays(clean))
+}
+
+func TestFilter_Blocklist(t *testing.T) {
+	f := New(filepath.Join(t.TempDir(), "blocklist.json"), ExplicitShow)
+	assert.NoError(t, f.BlockArtist(BlockedArtist{ID: "UC1", Name: "Some Artist"}))
+	assert.NoError(t, f.BlockTrack(BlockedTrack{VideoID: "v1", Name: "Some Song"}))
+
+	assert.True(t, f.IsBlocked(track("v1", "other", false)))
+	// artists are matched by name when the track does not carry their id
+	assert.True(t, f.IsBlocked(track("v2", "some artist", false)))
+	assert.True(t, f.IsBlocked(types.Track{ID: "v3", Artists: []types.Artist{{ID: "UC1", Name: "Renamed"}}}))
+	assert.False(t, f.IsBlocked(track("v4", "other", false)))
+
+	assert.NoError(t, f.UnblockTrack("v1"))
+	assert.NoError(t, f.UnblockArtist(BlockedArtist{ID: "UC1", Name: "Some Artist"}))
+	assert.Empty(t, f.Blocklist().Artists)
+	assert.Empty(t, f.Blocklist().Tracks)
+}
+
+func TestVisible(t *testing.T) {
+	f := New(filepath.Join(t.TempDir(), "blocklist.json"), ExplicitHide)
+	tracks := []types.Track{track("a", "x", true), track("b", "x", false)}
+	visible := Visible(f, tracks, func(track types.Track) types.Track { return track })
+	assert.Len(t, visible, 1)
+	assert.Equal(t, "b", visible[0].ID)
+}
+
+func TestParseExplicitMode(t *testing.T) {
+	mode, err := ParseExplicitMode(" Hide ")
+	assert.NoError(t, err)
+	assert.Equal(t, ExplicitHide, mode)
+
+	mode, err = ParseExplicitMode("show")
+	assert.NoError(t, err)
+	assert.Equal(t, ExplicitShow, mode)
+
+	mode, err = ParseExplicitMode("block")
+	assert.Error(t, err)
+	assert.Equal(t, ExplicitShow, mode)
+}
@@ -0,0 +1,80 @@
+package headless
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/palette"
+)
+
+type CommandRequest struct {
+	// a line of the ":" command palette, e.g. "vol 60" or "queue clear"
+	Command string `json:"command"`
+}
+
+// runCommand runs a command of the palette with the methods the API routes call, only the ones that act on the
+// player and the queue can run without the TUI
+func runCommand(p *player, call palette.Call) (string, error) {
+	switch call.Command.Name {
+	case string(keymap.PlayPause):
+		if p.togglePlayback() {
+			return "paused the playback", nil
+		}
+		return "resumed the playback", nil
+	case string(keymap.NextTrack), string(keymap.PreviousTrack):
+		direction := 1
+		if call.Command.Action == keymap.PreviousTrack {
+			direction = -1
+		}
+		track, err := p.step(direction)
+		if err != nil {
+			return "", err
+		}
+		return "playing " + track.Track.Name, nil
+	case string(keymap.Undo), string(keymap.Redo):
+		isRedo := call.Command.Action == keymap.Redo
+		label, _, err := p.restoreQueue(isRedo)
+		if err != nil {
+			return "", err
+		}
+		if isRedo {
+			return "redone: " + label, nil
+		}
+		return "undone: " + label, nil
+	case "queue", string(keymap.RemoveDuplicates):
+		if call.Args == "clear" {
+			p.clearQueue()
+			return "cleared the queue", nil
+		}
+		if call.Command.Name == "queue" && call.Args != "dedupe" {
+			return "", fmt.Errorf("unknown queue command %q, expected clear or dedupe", call.Args)
+		}
+		removed, _ := p.dedupeQueue()
+		return fmt.Sprintf("removed %d duplicates", removed), nil
+	case "seek":
+		position, err := p.seek(call.Args)
+		if err != nil {
+			return "", err
+		}
+		return "playing from " + position.String(), nil
+	case "vol":
+		volume, err := p.setVolume(call.Args)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("volume %d%%", volume), nil
+	case "sleep":
+		return p.setSleepTimer(call.Args)
+	case string(keymap.SaveQueue):
+		// there is no picker to choose a playlist from, the queue is saved as a new one
+		if call.Args == "" {
+			return "", errors.New("usage: save-queue <name>")
+		}
+		if _, err := p.saveQueue(call.Args); err != nil {
+			return "", err
+		}
+		return "saved the queue as " + call.Args, nil
+	}
+	return "", fmt.Errorf("%s is not available in headless mode", call.Command.Name)
+}
@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"sync"
+	"slices"
+	"strconv"
 	"time"
 
 	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/dedupe"
+	"github.com/kumneger0/clispot/internal/filter"
+	"github.com/kumneger0/clispot/internal/palette"
 	"github.com/kumneger0/clispot/internal/types"
 	"github.com/kumneger0/clispot/internal/ui"
 )
@@ -31,6 +36,8 @@ const (
 
 type TracksResponse struct {
 	Tracks []*types.PlaylistTrackObject `json:"tracks"`
+	// pass it back as the continuation query param to get the next page, empty on the last page
+	Continuation string `json:"continuation,omitempty"`
 }
 
 type SSEMessage struct {
@@ -54,6 +61,43 @@ type AddTrackToQueue struct {
 	Index int                       `json:"index"`
 }
 
+type SearchResponse struct {
+	types.SearchResponse
+	// a filtered search has more results, they are fetched with offset set to NextOffset
+	HasMore bool `json:"hasMore"`
+	// blocked results are left out of Items, so it is not always the offset plus the number of items
+	NextOffset int `json:"nextOffset"`
+}
+
+type RateTrackRequest struct {
+	// defaults to the track that is playing
+	TrackID string `json:"trackID"`
+	// for /player/like, false clears the like, defaults to flipping it
+	Like *bool `json:"like"`
+	// for /player/dislike, false clears the dislike, defaults to flipping it
+	Dislike *bool `json:"dislike"`
+}
+
+type SeekRequest struct {
+	// "1:30" or seconds, a "+" or "-" in front moves from the current position
+	Position string `json:"position"`
+}
+
+type VolumeRequest struct {
+	// a percent, a "+" or "-" in front changes the current volume by it
+	Volume string `json:"volume"`
+}
+
+type SleepRequest struct {
+	// e.g. "30m" or "off", empty tells how long is left
+	Duration string `json:"duration"`
+}
+
+type SaveQueueRequest struct {
+	// the name of the new playlist
+	Name string `json:"name"`
+}
+
 type RemoveTrackFromQueue struct {
 	Track types.PlaylistTrackObject `json:"track"`
 }
@@ -77,6 +121,53 @@ func (h *Queue) RemoveTrack(index int) {
 	h.Tracks = append(h.Tracks[:index], h.Tracks[index+1:]...)
 }
 
+func (h *Queue) Contains(track types.Track) bool {
+	set := dedupe.NewSet()
+	for _, queued := range h.Tracks {
+		if queued != nil {
+			set.Add(queued.Track)
+		}
+	}
+	return set.Contains(track)
+}
+
+// RemoveDuplicates keeps the first copy of every song, or the current one when it is a later copy, and returns how many were removed
+func (h *Queue) RemoveDuplicates() int {
+	var tracks []types.Track
+	var items []*types.PlaylistTrackObject
+	for _, item := range h.Tracks {
+		if item != nil {
+			tracks = append(tracks, item.Track)
+			items = append(items, item)
+		}
+	}
+	var currentID string
+	if h.CurrentIndex >= 0 && h.CurrentIndex < len(h.Tracks) && h.Tracks[h.CurrentIndex] != nil {
+		currentID = h.Tracks[h.CurrentIndex].Track.ID
+	}
+
+	unique := []*types.PlaylistTrackObject{}
+	currentIndex := 0
+	for _, index := range dedupe.Unique(tracks, currentID) {
+		if items[index].Track.ID == currentID {
+			currentIndex = len(unique)
+		}
+		unique = append(unique, items[index])
+	}
+	removed := len(h.Tracks) - len(unique)
+	h.Tracks = unique
+	h.CurrentIndex = currentIndex
+	return removed
+}
+
+// clone copies the queue so it can be kept in the undo history
+func (h *Queue) clone() Queue {
+	return Queue{
+		Tracks:       slices.Clone(h.Tracks),
+		CurrentIndex: h.CurrentIndex,
+	}
+}
+
 func NewMusicQueue() *Queue {
 	return &Queue{
 		Tracks:       []*types.PlaylistTrackObject{},
@@ -84,53 +175,56 @@ func NewMusicQueue() *Queue {
 	}
 }
 
+func playlistTrack(track *types.PlaylistTrackObject) types.Track {
+	return track.Track
+}
+
+// step moves the queue by one track in the given direction, passing over the tracks the content filter
+// rejects when skip-on-no-match is set and stopping on them otherwise
+func (q *Queue) step(direction int, f *filter.Filter) *types.PlaylistTrackObject {
+	count := len(q.Tracks)
+	for i := 1; i <= count; i++ {
+		index := ((q.CurrentIndex+i*direction)%count + count) % count
+		track := q.Tracks[index]
+		if track == nil {
+			continue
+		}
+		if !f.Plays(track.Track) {
+			if config.GetConfig().SkipOnNoMatch {
+				continue
+			}
+			q.CurrentIndex = index
+			return nil
+		}
+		q.CurrentIndex = index
+		return track
+	}
+	return nil
+}
+
 func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
-	musicQueue := NewMusicQueue()
-	var mqMu sync.Mutex
+	p := newPlayer(m)
 
 	go func() {
 		if dbusMessageChan == nil {
 			return
 		}
 		for msg := range *dbusMessageChan {
-			mqMu.Lock()
+			var err error
 			switch msg.MessageType {
 			case types.NextTrack:
-				if len(musicQueue.Tracks) == 0 {
-					continue
-				}
-				nextTrackIndex := musicQueue.CurrentIndex + 1
-				if nextTrackIndex >= len(musicQueue.Tracks) {
-					nextTrackIndex = 0
-				}
-				musicQueue.CurrentIndex = nextTrackIndex
-				nextTrack := musicQueue.Tracks[musicQueue.CurrentIndex]
-				if nextTrack != nil {
-					//the code this in this function is only executed when user clicks on
-					// control button on his/her desktop environment
-					//which means it is skip
-					model, _ := m.PlaySelectedMusic(*nextTrack)
-					m.Model = &model
-				}
+				//the code this in this function is only executed when user clicks on
+				// control button on his/her desktop environment
+				//which means it is skip
+				_, err = p.step(1)
 			case types.PlayPause:
-				model, _ := m.HandleMusicPausePlay()
-				m.Model = &model
+				p.togglePlayback()
 			case types.PreviousTrack:
-				if len(musicQueue.Tracks) == 0 {
-					continue
-				}
-				prevTrackIndex := musicQueue.CurrentIndex - 1
-				if prevTrackIndex < 0 {
-					prevTrackIndex = len(musicQueue.Tracks) - 1
-				}
-				musicQueue.CurrentIndex = prevTrackIndex
-				prevTrack := musicQueue.Tracks[musicQueue.CurrentIndex]
-				if prevTrack != nil {
-					model, _ := m.PlaySelectedMusic(*prevTrack)
-					m.Model = &model
-				}
+				_, err = p.step(-1)
+			}
+			if err != nil {
+				slog.Warn(err.Error())
 			}
-			mqMu.Unlock()
 		}
 	}()
 
@@ -227,6 +321,8 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		}
 	})
 
+	registerPlaylistRoutes(mux, m)
+
 	mux.HandleFunc("/tracks", func(w http.ResponseWriter, r *http.Request) {
 		m.Mu.RLock()
 		defer m.Mu.RUnlock()
@@ -243,6 +339,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 			http.Error(w, `{"error":"missing required query param: type"}`, http.StatusBadRequest)
 			return
 		}
+		continuation := r.URL.Query().Get("continuation")
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -261,7 +358,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 				})
 			}
 
-			resp := &TracksResponse{Tracks: tracks}
+			resp := &TracksResponse{Tracks: filter.Visible(m.Filter, tracks, playlistTrack)}
 			data, err := json.Marshal(resp)
 			if err != nil {
 				slog.Error(err.Error())
@@ -278,7 +375,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		}
 
 		if TracksType(queryType) == PlaylistType {
-			playlistItems, err := m.YtMusicClient.GetPlaylistItems(ctx, &musicpb.GetPlaylistItemsRequest{PlaylistId: id})
+			playlistItems, err := m.YtMusicClient.GetPlaylistItems(ctx, &musicpb.GetPlaylistItemsRequest{PlaylistId: id, Continuation: continuation})
 			if err != nil {
 				slog.Error(err.Error())
 				http.Error(w, `{"error":"failed to fetch playlist items"}`, http.StatusInternalServerError)
@@ -292,7 +389,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 				})
 			}
 
-			resp := &TracksResponse{Tracks: tracks}
+			resp := &TracksResponse{Tracks: filter.Visible(m.Filter, tracks, playlistTrack), Continuation: playlistItems.Continuation}
 			data, err := json.Marshal(resp)
 			if err != nil {
 				slog.Error(err.Error())
@@ -309,7 +406,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		}
 
 		if TracksType(queryType) == LikedSongs {
-			savedTracks, err := m.YtMusicClient.GetUserSavedTracks(ctx, &musicpb.GetUserSavedTracksRequest{})
+			savedTracks, err := m.YtMusicClient.GetUserSavedTracks(ctx, &musicpb.GetUserSavedTracksRequest{Continuation: continuation})
 			if err != nil {
 				slog.Error(err.Error())
 				http.Error(w, `{"error":"failed to fetch saved tracks"}`, http.StatusInternalServerError)
@@ -323,7 +420,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 				})
 			}
 
-			resp := &TracksResponse{Tracks: tracks}
+			resp := &TracksResponse{Tracks: filter.Visible(m.Filter, tracks, playlistTrack), Continuation: savedTracks.Continuation}
 			data, err := json.Marshal(resp)
 			if err != nil {
 				slog.Error(err.Error())
@@ -354,7 +451,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 				})
 			}
 
-			resp := &TracksResponse{Tracks: trackObject}
+			resp := &TracksResponse{Tracks: filter.Visible(m.Filter, trackObject, playlistTrack)}
 			data, err := json.Marshal(resp)
 			if err != nil {
 				slog.Error(err.Error())
@@ -388,7 +485,12 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		searchResults, err := m.YtMusicClient.GetSearchResults(ctx, &musicpb.GetSearchResultsRequest{Query: query})
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		searchResults, err := m.YtMusicClient.GetSearchResults(ctx, &musicpb.GetSearchResultsRequest{
+			Query:  query,
+			Filter: r.URL.Query().Get("filter"),
+			Offset: int32(offset),
+		})
 		if err != nil {
 			slog.Error(err.Error())
 			http.Error(w, `{"error":"failed to search"}`, http.StatusInternalServerError)
@@ -399,6 +501,9 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		for _, s := range searchResults.Songs {
 			tracks = append(tracks, types.MapSearchResultSongToTrack(s))
 		}
+		for _, v := range searchResults.Videos {
+			tracks = append(tracks, types.MapSearchResultSongToTrack(v))
+		}
 		var artists []types.Artist
 		for _, a := range searchResults.Artists {
 			artists = append(artists, types.Artist{
@@ -429,7 +534,24 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 			})
 		}
 
-		resp := &types.SearchResponse{}
+		resp := &SearchResponse{
+			HasMore:    searchResults.HasMore,
+			NextOffset: offset + len(searchResults.Songs) + len(searchResults.Videos) + len(artists) + len(playlists) + len(albums),
+		}
+		for _, track := range filter.Visible(m.Filter, tracks, func(track types.Track) types.Track { return track }) {
+			resp.Items = append(resp.Items, track)
+		}
+		for _, artist := range artists {
+			if !m.Filter.IsArtistBlocked(artist) {
+				resp.Items = append(resp.Items, artist)
+			}
+		}
+		for _, playlist := range playlists {
+			resp.Items = append(resp.Items, playlist)
+		}
+		for _, album := range albums {
+			resp.Items = append(resp.Items, album)
+		}
 
 		data, err := json.Marshal(resp)
 		if err != nil {
@@ -446,16 +568,11 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 	})
 
 	mux.HandleFunc("/player", func(w http.ResponseWriter, r *http.Request) {
-		m.Mu.Lock()
-		defer m.Mu.Unlock()
 		w.Header().Set("Content-Type", "application/json")
-		var action string
-		if m.PlayerProcess != nil && m.PlayerProcess.OtoPlayer.IsPlaying() {
+		action := "play"
+		if p.togglePlayback() {
 			action = "paused"
-		} else {
-			action = "play"
 		}
-		m.HandleMusicPausePlay()
 		resp := map[string]any{
 			"status": "ok",
 			"action": action,
@@ -501,7 +618,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		m.Model = &model
 
 		if reqBody.Queue != nil {
-			musicQueue = reqBody.Queue
+			p.replaceQueue(reqBody.Queue)
 		}
 
 		var trackObject *types.PlaylistTrackObject
@@ -561,11 +678,84 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 		}
 	})
 
+	handleRate := func(rating types.Rating) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			var reqBody RateTrackRequest
+			if r.Body != nil && r.ContentLength != 0 {
+				defer r.Body.Close()
+				if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+					slog.Error("failed to decode request body: " + err.Error())
+					http.Error(w, `{"message":"failed to decode request body", "status":"error"}`, http.StatusBadRequest)
+					return
+				}
+			}
+
+			// the lock is not held across the request to youtube music so the other handlers are not blocked by it
+			m.Mu.RLock()
+			model := m.Model
+			track := types.Track{ID: reqBody.TrackID}
+			if track.ID == "" {
+				if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+					m.Mu.RUnlock()
+					http.Error(w, `{"message":"nothing is playing, trackID is required", "status":"error"}`, http.StatusBadRequest)
+					return
+				}
+				track = m.SelectedTrack.Track.Track
+			} else if m.SelectedTrack != nil && m.SelectedTrack.Track != nil && m.SelectedTrack.Track.Track.ID == track.ID {
+				track = m.SelectedTrack.Track.Track
+			}
+			next := m.ToggleRating(track, rating)
+			m.Mu.RUnlock()
+
+			set := reqBody.Like
+			if rating == types.RatingDislike {
+				set = reqBody.Dislike
+			}
+			if set != nil {
+				next = types.RatingNone
+				if *set {
+					next = rating
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := model.RateTrack(ctx, track.ID, next); err != nil {
+				slog.Error(err.Error())
+				http.Error(w, `{"message":"failed to rate the track", "status":"error"}`, http.StatusInternalServerError)
+				return
+			}
+			m.Mu.Lock()
+			m.SetTrackRating(track.ID, next)
+			m.Mu.Unlock()
+
+			data, err := json.Marshal(map[string]any{
+				"status":   "success",
+				"trackID":  track.ID,
+				"liked":    next == types.RatingLike,
+				"disliked": next == types.RatingDislike,
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				http.Error(w, `{"message":"failed to encode response", "status":"error"}`, http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(data)
+			if err != nil {
+				slog.Error(err.Error())
+			}
+		}
+	}
+
+	mux.HandleFunc("POST /player/like", handleRate(types.RatingLike))
+	mux.HandleFunc("POST /player/dislike", handleRate(types.RatingDislike))
+
 	mux.HandleFunc("GET /player/queue", func(w http.ResponseWriter, r *http.Request) {
-		mqMu.Lock()
-		defer mqMu.Unlock()
 		w.Header().Set("Content-Type", "application/json")
-		data, err := json.Marshal(musicQueue)
+		data, err := json.Marshal(p.snapshot())
 		if err != nil {
 			slog.Error(err.Error())
 			http.Error(w, `{"message":"failed to encode response", "status":"error"}`, http.StatusBadRequest)
@@ -579,86 +769,164 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 	})
 
 	mux.HandleFunc("POST /player/queue/add", func(w http.ResponseWriter, r *http.Request) {
-		mqMu.Lock()
-		defer mqMu.Unlock()
 		w.Header().Set("Content-Type", "application/json")
 		var reqBody AddTrackToQueue
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
-		if err != nil {
-			slog.Error("failed to decode request body: " + err.Error())
-			fmt.Println("failed to add to queue", reqBody)
-			http.Error(w, `{"message":"failed to decode request body", "status":"error"}`, http.StatusBadRequest)
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		if err := p.addTrack(&reqBody.Track, reqBody.Index); err != nil {
+			writeError(w, err.Error(), http.StatusConflict)
 			return
 		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"message": "track added to queue",
+		})
+	})
 
-		musicQueue.AddTrack(&reqBody.Track, reqBody.Index)
+	mux.HandleFunc("DELETE /player/queue/remove", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var reqBody RemoveTrackFromQueue
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		if err := p.removeTrack(reqBody.Track.Track.ID); err != nil {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"message": "track removed from queue",
+		})
+	})
 
-		w.WriteHeader(http.StatusOK)
-		data, err := json.Marshal(map[string]any{
+	mux.HandleFunc("POST /player/queue/dedupe", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		removed, queue := p.dedupeQueue()
+		writeJSON(w, http.StatusOK, map[string]any{
 			"status":  "success",
-			"message": "track added to queue",
+			"removed": removed,
+			"queue":   queue,
 		})
-		if err != nil {
-			slog.Error(err.Error())
-			http.Error(w, `{"message":"failed to encode response", "status":"error"}`, http.StatusBadRequest)
+	})
+
+	handleQueueHistory := func(isRedo bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			label, queue, err := p.restoreQueue(isRedo)
+			if err != nil {
+				writeError(w, err.Error(), http.StatusConflict)
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{
+				"status":  "success",
+				"message": label,
+				"queue":   queue,
+			})
+		}
+	}
+
+	mux.HandleFunc("POST /player/queue/undo", handleQueueHistory(false))
+	mux.HandleFunc("POST /player/queue/redo", handleQueueHistory(true))
+
+	mux.HandleFunc("POST /player/queue/clear", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status": "success",
+			"queue":  p.clearQueue(),
+		})
+	})
+
+	mux.HandleFunc("POST /player/queue/save", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var reqBody SaveQueueRequest
+		if !decodeBody(w, r, &reqBody) {
 			return
 		}
-		_, err = w.Write(data)
+		playlistID, err := p.saveQueue(reqBody.Name)
 		if err != nil {
 			slog.Error(err.Error())
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"status":     "success",
+			"playlistId": playlistID,
+		})
 	})
 
-	mux.HandleFunc("DELETE /player/queue/remove", func(w http.ResponseWriter, r *http.Request) {
-		mqMu.Lock()
-		defer mqMu.Unlock()
+	mux.HandleFunc("POST /player/seek", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		var reqBody RemoveTrackFromQueue
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		var reqBody SeekRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		position, err := p.seek(reqBody.Position)
 		if err != nil {
-			slog.Error("failed to decode request body: " + err.Error())
-			http.Error(w, `{"message":"failed to decode request body", "status":"error"}`, http.StatusBadRequest)
+			writeError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		var index int = -1
-		for i, track := range musicQueue.Tracks {
-			if track.Track.ID == reqBody.Track.Track.ID {
-				index = i
-				break
-			}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":        "success",
+			"secondsPlayed": position.Seconds(),
+		})
+	})
+
+	mux.HandleFunc("POST /player/volume", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var reqBody VolumeRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
 		}
-		if index == -1 {
-			http.Error(w, `{"message":"track not found", "status":"error"}`, http.StatusNotFound)
+		volume, err := p.setVolume(reqBody.Volume)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status": "success",
+			"volume": volume,
+		})
+	})
 
-		musicQueue.RemoveTrack(index)
-		// Adjust CurrentIndex if necessary
-		if index < musicQueue.CurrentIndex {
-			musicQueue.CurrentIndex--
-		} else if index == musicQueue.CurrentIndex && musicQueue.CurrentIndex >= len(musicQueue.Tracks) {
-			// If we removed the current track and it was the last one, adjust to the new last track
-			if len(musicQueue.Tracks) > 0 {
-				musicQueue.CurrentIndex = len(musicQueue.Tracks) - 1
-			} else {
-				musicQueue.CurrentIndex = 0
-			}
+	mux.HandleFunc("POST /player/sleep", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var reqBody SleepRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
 		}
-
-		w.WriteHeader(http.StatusOK)
-		data, err := json.Marshal(map[string]any{
+		message, err := p.setSleepTimer(reqBody.Duration)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
 			"status":  "success",
-			"message": "track removed from queue",
+			"message": message,
 		})
+	})
+
+	mux.HandleFunc("POST /command", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var reqBody CommandRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		call, err := palette.Parse(reqBody.Command)
 		if err != nil {
-			slog.Error(err.Error())
+			writeError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-
-		_, err = w.Write(data)
+		message, err := runCommand(p, call)
 		if err != nil {
-			slog.Error(err.Error())
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"message": message,
+			"queue":   p.snapshot(),
+		})
 	})
 
 	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
@@ -687,7 +955,7 @@ func StartServer(m *ui.SafeModel, dbusMessageChan *chan types.DBusMessage) {
 			case <-ticker.C:
 				m.Mu.RLock()
 				if m.PlayerProcess != nil && m.PlayerProcess.ByteCounterReader != nil {
-					currentIndex := musicQueue.CurrentIndex
+					currentIndex := p.snapshot().CurrentIndex
 					isPlaying := m.PlayerProcess != nil && m.PlayerProcess.OtoPlayer.IsPlaying()
 					seconds := m.PlayerProcess.ByteCounterReader.CurrentSeconds()
 
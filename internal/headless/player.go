@@ -0,0 +1,304 @@
+package headless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/palette"
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/kumneger0/clispot/internal/ui"
+	"github.com/kumneger0/clispot/internal/undo"
+)
+
+// player is the playback and the queue of the server, the API routes, the media keys and the commands of the
+// palette all act on it through its methods
+type player struct {
+	m *ui.SafeModel
+	// mu guards the fields below, it is taken after m.Mu
+	mu      sync.Mutex
+	queue   *Queue
+	history *undo.Stack[Queue]
+	// when the sleep timer pauses the playback, zero while none is set
+	sleepAt time.Time
+	// a timer that was replaced or turned off does nothing when it fires
+	sleepSeq int
+}
+
+func newPlayer(m *ui.SafeModel) *player {
+	return &player{
+		m:       m,
+		queue:   NewMusicQueue(),
+		history: undo.New[Queue](undo.DefaultDepth),
+	}
+}
+
+// isPlaying needs m.Mu held
+func (p *player) isPlaying() bool {
+	return p.m.PlayerProcess != nil && p.m.PlayerProcess.OtoPlayer != nil && p.m.PlayerProcess.OtoPlayer.IsPlaying()
+}
+
+// togglePlayback pauses or resumes the playback and returns whether it was playing
+func (p *player) togglePlayback() bool {
+	p.m.Mu.Lock()
+	defer p.m.Mu.Unlock()
+	playing := p.isPlaying()
+	model, _ := p.m.HandleMusicPausePlay()
+	p.m.Model = &model
+	return playing
+}
+
+// step plays the next track of the queue, or the previous one when direction is -1
+func (p *player) step(direction int) (*types.PlaylistTrackObject, error) {
+	p.m.Mu.Lock()
+	defer p.m.Mu.Unlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.queue.Tracks) == 0 {
+		return nil, errors.New("the queue is empty")
+	}
+	track := p.queue.step(direction, p.m.Filter)
+	if track == nil {
+		return nil, errors.New("the track can not be played")
+	}
+	model, _ := p.m.PlaySelectedMusic(*track)
+	p.m.Model = &model
+	return track, nil
+}
+
+// seek plays the current track from position, see palette.ParsePosition, and returns where it plays from once the
+// stream is open again
+func (p *player) seek(position string) (time.Duration, error) {
+	p.m.Mu.Lock()
+	if p.m.SelectedTrack == nil || p.m.SelectedTrack.Track == nil {
+		p.m.Mu.Unlock()
+		return 0, errors.New("nothing is playing")
+	}
+	// nothing updates the played seconds of the model without the TUI
+	current := p.m.PlayedSeconds
+	if p.m.PlayerProcess != nil && p.m.PlayerProcess.ByteCounterReader != nil {
+		current = p.m.PlayerProcess.ByteCounterReader.CurrentSeconds()
+	}
+	at, err := palette.ParsePosition(position, time.Duration(current*float64(time.Second)))
+	if err != nil {
+		p.m.Mu.Unlock()
+		return 0, err
+	}
+	model, cmd := p.m.Seek(at)
+	p.m.Model = &model
+	p.m.Mu.Unlock()
+
+	if err := p.openStream(cmd); err != nil {
+		return 0, err
+	}
+	return time.Duration(model.PlayedSeconds * float64(time.Second)), nil
+}
+
+// openStream runs the command a playback was started with, the model closed the stream it played before and only
+// the command opens the new one, its player is kept like Update keeps it
+func (p *player) openStream(cmd tea.Cmd) error {
+	if cmd == nil {
+		return nil
+	}
+	// the lock is not held while the stream is opened, it waits for the backend and ffmpeg
+	msg, ok := cmd().(types.SearchAndDownloadMusicMsg)
+	if !ok {
+		// a later playback cancelled this one
+		return nil
+	}
+	if msg.Err != nil {
+		return msg.Err
+	}
+	if msg.Player == nil {
+		return errors.New("failed to open the stream")
+	}
+	p.m.Mu.Lock()
+	defer p.m.Mu.Unlock()
+	if p.m.SelectedTrack == nil || p.m.SelectedTrack.Track == nil || p.m.SelectedTrack.Track.Track.ID != msg.VideoID {
+		return msg.Player.Close()
+	}
+	p.m.PlayerProcess = msg.Player
+	return nil
+}
+
+// setVolume reads the volume with palette.ParseVolume and returns the percent it was set to
+func (p *player) setVolume(volume string) (int, error) {
+	p.m.Mu.Lock()
+	defer p.m.Mu.Unlock()
+	percent, err := palette.ParseVolume(volume, p.m.Volume)
+	if err != nil {
+		return 0, err
+	}
+	model := p.m.SetVolume(percent)
+	p.m.Model = &model
+	return percent, nil
+}
+
+// setSleepTimer pauses the playback after the duration, see palette.ParseSleep, without one it tells how long is left
+func (p *player) setSleepTimer(duration string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if duration == "" {
+		if p.sleepAt.IsZero() {
+			return "no sleep timer is set", nil
+		}
+		return "pausing in " + time.Until(p.sleepAt).Round(time.Second).String(), nil
+	}
+	d, err := palette.ParseSleep(duration)
+	if err != nil {
+		return "", err
+	}
+	p.sleepSeq++
+	if d == 0 {
+		p.sleepAt = time.Time{}
+		return "sleep timer off", nil
+	}
+	p.sleepAt = time.Now().Add(d)
+	seq := p.sleepSeq
+	time.AfterFunc(d, func() { p.sleep(seq) })
+	return "pausing in " + d.String(), nil
+}
+
+func (p *player) sleep(seq int) {
+	p.m.Mu.Lock()
+	defer p.m.Mu.Unlock()
+	p.mu.Lock()
+	current := seq == p.sleepSeq && !p.sleepAt.IsZero()
+	if current {
+		p.sleepAt = time.Time{}
+	}
+	p.mu.Unlock()
+	if !current || !p.isPlaying() {
+		return
+	}
+	model, _ := p.m.HandleMusicPausePlay()
+	p.m.Model = &model
+}
+
+// snapshot copies the queue so it can be encoded without holding the lock
+func (p *player) snapshot() Queue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queue.clone()
+}
+
+// replaceQueue plays from the queue the client sent from now on
+func (p *player) replaceQueue(queue *Queue) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.history.Record("replace queue", p.queue.clone())
+	p.queue = queue
+}
+
+// addTrack puts the track at index of the queue, or at its end when index is out of range
+func (p *player) addTrack(track *types.PlaylistTrackObject, index int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if config.GetConfig().PreventDuplicates && p.queue.Contains(track.Track) {
+		return errors.New("track is already in the queue")
+	}
+	p.history.Record("add track", p.queue.clone())
+	p.queue.AddTrack(track, index)
+	return nil
+}
+
+// removeTrack removes the first copy of the track from the queue, the current track stays current
+func (p *player) removeTrack(trackID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	index := slices.IndexFunc(p.queue.Tracks, func(track *types.PlaylistTrackObject) bool {
+		return track != nil && track.Track.ID == trackID
+	})
+	if index == -1 {
+		return errors.New("track not found")
+	}
+
+	p.history.Record("remove track", p.queue.clone())
+	p.queue.RemoveTrack(index)
+	if index < p.queue.CurrentIndex {
+		p.queue.CurrentIndex--
+	} else if index == p.queue.CurrentIndex && p.queue.CurrentIndex >= len(p.queue.Tracks) {
+		// the last track was the current one, the new last track is
+		p.queue.CurrentIndex = max(len(p.queue.Tracks)-1, 0)
+	}
+	return nil
+}
+
+func (p *player) clearQueue() Queue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.history.Record("clear queue", p.queue.clone())
+	p.queue = NewMusicQueue()
+	return p.queue.clone()
+}
+
+// dedupeQueue removes the duplicates of the queue and returns how many there were
+func (p *player) dedupeQueue() (int, Queue) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	before := p.queue.clone()
+	removed := p.queue.RemoveDuplicates()
+	if removed > 0 {
+		p.history.Record("remove duplicates", before)
+	}
+	return removed, p.queue.clone()
+}
+
+// restoreQueue undoes the last change of the queue, or redoes the last undone one, and returns its label
+func (p *player) restoreQueue(isRedo bool) (string, Queue, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var snapshot undo.Snapshot[Queue]
+	var ok bool
+	if isRedo {
+		snapshot, ok = p.history.Redo(p.queue.clone())
+	} else {
+		snapshot, ok = p.history.Undo(p.queue.clone())
+	}
+	if !ok {
+		if isRedo {
+			return "", Queue{}, errors.New("nothing to redo")
+		}
+		return "", Queue{}, errors.New("nothing to undo")
+	}
+	p.queue = &snapshot.State
+	return snapshot.Label, p.queue.clone(), nil
+}
+
+// saveQueue saves the queue as a new private playlist and returns its id
+func (p *player) saveQueue(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", errors.New("a name for the playlist is required")
+	}
+	var videoIDs []string
+	for _, track := range p.snapshot().Tracks {
+		if track != nil && track.Track.ID != "" {
+			videoIDs = append(videoIDs, track.Track.ID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return "", errors.New("the queue is empty")
+	}
+
+	p.m.Mu.RLock()
+	client := p.m.YtMusicClient
+	p.m.Mu.RUnlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp, err := client.CreatePlaylist(ctx, &musicpb.CreatePlaylistRequest{
+		Title:         name,
+		PrivacyStatus: "PRIVATE",
+		VideoIds:      videoIDs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save the queue: %w", err)
+	}
+	return resp.PlaylistId, nil
+}
@@ -0,0 +1,229 @@
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/ui"
+)
+
+type CreatePlaylistRequest struct {
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	PrivacyStatus string   `json:"privacyStatus"`
+	VideoIDs      []string `json:"videoIds"`
+}
+
+// EditPlaylistRequest leaves empty fields unchanged
+type EditPlaylistRequest struct {
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	PrivacyStatus string `json:"privacyStatus"`
+}
+
+type AddPlaylistTracksRequest struct {
+	VideoIDs        []string `json:"videoIds"`
+	AllowDuplicates bool     `json:"allowDuplicates"`
+}
+
+type RemovePlaylistTracksRequest struct {
+	Tracks []struct {
+		VideoID string `json:"videoId"`
+		// the backend looks it up from the playlist when empty
+		SetVideoID string `json:"setVideoId"`
+	} `json:"tracks"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		slog.Error(err.Error())
+		http.Error(w, `{"message":"failed to encode response", "status":"error"}`, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, message string, status int) {
+	writeJSON(w, status, map[string]any{"status": "error", "message": message})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Body == nil {
+		http.Error(w, `{"message":"request body required", "status":"error"}`, http.StatusBadRequest)
+		return false
+	}
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		slog.Error("failed to decode request body: " + err.Error())
+		http.Error(w, `{"message":"failed to decode request body", "status":"error"}`, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func registerPlaylistRoutes(mux *http.ServeMux, m *ui.SafeModel) {
+	mux.HandleFunc("POST /playlists", func(w http.ResponseWriter, r *http.Request) {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		var reqBody CreatePlaylistRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		if strings.TrimSpace(reqBody.Title) == "" {
+			http.Error(w, `{"message":"title is required", "status":"error"}`, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		resp, err := m.YtMusicClient.CreatePlaylist(ctx, &musicpb.CreatePlaylistRequest{
+			Title:         reqBody.Title,
+			Description:   reqBody.Description,
+			PrivacyStatus: reqBody.PrivacyStatus,
+			VideoIds:      reqBody.VideoIDs,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			http.Error(w, `{"message":"failed to create the playlist", "status":"error"}`, http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"status":     "success",
+			"playlistId": resp.PlaylistId,
+		})
+	})
+
+	mux.HandleFunc("PATCH /playlists/{id}", func(w http.ResponseWriter, r *http.Request) {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		var reqBody EditPlaylistRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.EditPlaylist(ctx, &musicpb.EditPlaylistRequest{
+			PlaylistId:    r.PathValue("id"),
+			Title:         reqBody.Title,
+			Description:   reqBody.Description,
+			PrivacyStatus: reqBody.PrivacyStatus,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			http.Error(w, `{"message":"failed to edit the playlist", "status":"error"}`, http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"message": "playlist updated",
+		})
+	})
+
+	mux.HandleFunc("DELETE /playlists/{id}", func(w http.ResponseWriter, r *http.Request) {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.DeletePlaylist(ctx, &musicpb.DeletePlaylistRequest{
+			PlaylistId: r.PathValue("id"),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			http.Error(w, `{"message":"failed to delete the playlist", "status":"error"}`, http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"message": "playlist deleted",
+		})
+	})
+
+	mux.HandleFunc("POST /playlists/{id}/tracks", func(w http.ResponseWriter, r *http.Request) {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		var reqBody AddPlaylistTracksRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		if len(reqBody.VideoIDs) == 0 {
+			http.Error(w, `{"message":"videoIds is required", "status":"error"}`, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.AddPlaylistItems(ctx, &musicpb.AddPlaylistItemsRequest{
+			PlaylistId:      r.PathValue("id"),
+			VideoIds:        reqBody.VideoIDs,
+			AllowDuplicates: reqBody.AllowDuplicates,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			http.Error(w, `{"message":"failed to add the tracks", "status":"error"}`, http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status": "success",
+			"added":  len(reqBody.VideoIDs),
+		})
+	})
+
+	mux.HandleFunc("DELETE /playlists/{id}/tracks", func(w http.ResponseWriter, r *http.Request) {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		var reqBody RemovePlaylistTracksRequest
+		if !decodeBody(w, r, &reqBody) {
+			return
+		}
+		var items []*musicpb.PlaylistItem
+		for _, track := range reqBody.Tracks {
+			if track.VideoID == "" && track.SetVideoID == "" {
+				continue
+			}
+			items = append(items, &musicpb.PlaylistItem{
+				VideoId:    track.VideoID,
+				SetVideoId: track.SetVideoID,
+			})
+		}
+		if len(items) == 0 {
+			http.Error(w, `{"message":"tracks is required", "status":"error"}`, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.RemovePlaylistItems(ctx, &musicpb.RemovePlaylistItemsRequest{
+			PlaylistId: r.PathValue("id"),
+			Items:      items,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			http.Error(w, `{"message":"failed to remove the tracks", "status":"error"}`, http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  "success",
+			"removed": len(items),
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package history
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/jsonfile"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+const (
+	// a play is only recorded once it has lasted this long or has reached MinPlayedRatio of the track
+	MinPlayedSeconds = 30
+	MinPlayedRatio   = 0.5
+	// MaxEntries keeps the history file from growing forever, the oldest entries are dropped first
+	MaxEntries = 1000
+)
+
+type Entry struct {
+	Track    types.Track `json:"track"`
+	PlayedAt time.Time   `json:"playedAt"`
+}
+
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	entries []Entry
+}
+
+func DefaultPath(goos string) string {
+	return filepath.Join(config.GetStateDir(goos), "history.json")
+}
+
+// New loads the history stored at path
+func New(path string) *Store {
+	return &Store{path: path, entries: jsonfile.Load[[]Entry](path, "playback history")}
+}
+
+func ShouldRecord(playedSeconds, totalSeconds float64) bool {
+	if playedSeconds >= MinPlayedSeconds {
+		return true
+	}
+	return totalSeconds > 0 && playedSeconds/totalSeconds >= MinPlayedRatio
+}
+
+func (s *Store) Add(track types.Track, playedAt time.Time) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, Entry{Track: track, PlayedAt: playedAt})
+	if len(s.entries) > MaxEntries {
+		s.entries = s.entries[len(s.entries)-MaxEntries:]
+	}
+	return jsonfile.Save(s.path, s.entries)
+}
+
+// Entries returns a copy of the history ordered from the oldest to the newest play
+func (s *Store) Entries() []Entry {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]Entry, len(s.entries))
+	copy(entries, s.entries)
+	return entries
+}
+
+// Recent returns up to limit entries ordered from the newest to the oldest play, a limit <= 0 returns everything
+func (s *Store) Recent(limit int) []Entry {
+	entries := s.Entries()
+	if limit <= 0 || limit > len(entries) {
+		limit = len(entries)
+	}
+	recent := make([]Entry, 0, limit)
+	for i := len(entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, entries[i])
+	}
+	return recent
+}
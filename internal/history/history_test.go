@@ -0,0 +1,68 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRecord(t *testing.T) {
+	assert.False(t, ShouldRecord(10, 300))
+	assert.True(t, ShouldRecord(30, 300))
+	assert.True(t, ShouldRecord(25, 40))
+	assert.False(t, ShouldRecord(0, 0))
+}
+
+func TestStore_AddPersistsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "history.json")
+	store := New(path)
+	playedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := store.Add(types.Track{ID: "a", Name: "first"}, playedAt)
+	assert.NoError(t, err)
+	err = store.Add(types.Track{ID: "b", Name: "second"}, playedAt.Add(time.Minute))
+	assert.NoError(t, err)
+
+	reloaded := New(path)
+	entries := reloaded.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Track.ID)
+	assert.True(t, playedAt.Equal(entries[0].PlayedAt))
+	assert.Equal(t, "b", entries[1].Track.ID)
+}
+
+func TestStore_RecentIsNewestFirst(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "history.json"))
+	now := time.Now()
+	for _, id := range []string{"a", "b", "c"} {
+		assert.NoError(t, store.Add(types.Track{ID: id}, now))
+	}
+
+	recent := store.Recent(2)
+	assert.Len(t, recent, 2)
+	assert.Equal(t, "c", recent[0].Track.ID)
+	assert.Equal(t, "b", recent[1].Track.ID)
+	assert.Len(t, store.Recent(0), 3)
+}
+
+func TestStore_DropsOldestEntriesOverLimit(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "history.json"))
+	for i := 0; i < MaxEntries+5; i++ {
+		store.entries = append(store.entries, Entry{Track: types.Track{ID: "old"}})
+	}
+	assert.NoError(t, store.Add(types.Track{ID: "new"}, time.Now()))
+
+	entries := store.Entries()
+	assert.Len(t, entries, MaxEntries)
+	assert.Equal(t, "new", entries[len(entries)-1].Track.ID)
+}
+
+func TestStore_NilIsNoop(t *testing.T) {
+	var store *Store
+	assert.NoError(t, store.Add(types.Track{ID: "a"}, time.Now()))
+	assert.Nil(t, store.Entries())
+	assert.Empty(t, store.Recent(10))
+}
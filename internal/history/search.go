@@ -0,0 +1,66 @@
+package history
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kumneger0/clispot/internal/jsonfile"
+)
+
+// MaxSearches is the number of queries the search history keeps, the oldest ones are dropped first
+const MaxSearches = 200
+
+// SearchStore is the persisted list of queries that were searched, a query searched again moves to the end
+type SearchStore struct {
+	mu      sync.Mutex
+	path    string
+	queries []string
+}
+
+func SearchPath(goos string) string {
+	return filepath.Join(filepath.Dir(DefaultPath(goos)), "search_history.json")
+}
+
+// NewSearch loads the search history stored at path
+func NewSearch(path string) *SearchStore {
+	return &SearchStore{path: path, queries: jsonfile.Load[[]string](path, "search history")}
+}
+
+func (s *SearchStore) Add(query string) error {
+	query = strings.TrimSpace(query)
+	if s == nil || query == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = slices.DeleteFunc(s.queries, func(q string) bool { return q == query })
+	s.queries = append(s.queries, query)
+	if len(s.queries) > MaxSearches {
+		s.queries = s.queries[len(s.queries)-MaxSearches:]
+	}
+	return jsonfile.Save(s.path, s.queries)
+}
+
+// Recent returns the query searched n searches ago, 1 being the last one, and false past the oldest query
+func (s *SearchStore) Recent(n int) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 1 || n > len(s.queries) {
+		return "", false
+	}
+	return s.queries[len(s.queries)-n], true
+}
+
+func (s *SearchStore) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queries)
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchStore_AddMovesRepeatedQueriesToTheEnd(t *testing.T) {
+	store := NewSearch(filepath.Join(t.TempDir(), "search_history.json"))
+	assert.NoError(t, store.Add("daft punk"))
+	assert.NoError(t, store.Add("  "))
+	assert.NoError(t, store.Add("justice"))
+	assert.NoError(t, store.Add(" daft punk "))
+
+	assert.Equal(t, 2, store.Len())
+	last, _ := store.Recent(1)
+	assert.Equal(t, "daft punk", last)
+	first, _ := store.Recent(2)
+	assert.Equal(t, "justice", first)
+}
+
+func TestSearchStore_KeepsMaxSearches(t *testing.T) {
+	store := NewSearch(filepath.Join(t.TempDir(), "search_history.json"))
+	for i := 0; i < MaxSearches+5; i++ {
+		assert.NoError(t, store.Add(fmt.Sprintf("query %d", i)))
+	}
+	assert.Equal(t, MaxSearches, store.Len())
+	oldest, _ := store.Recent(MaxSearches)
+	assert.Equal(t, "query 5", oldest)
+	_, ok := store.Recent(MaxSearches + 1)
+	assert.False(t, ok)
+}
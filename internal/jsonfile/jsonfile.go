@@ -0,0 +1,48 @@
+package jsonfile
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Load reads the json file at path, a missing or unreadable file results in the zero value, name is the file in the
+// log, e.g. "playback history"
+func Load[T any](path, name string) T {
+	var v T
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Error("failed to read the "+name, "err", err)
+		}
+		return v
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		slog.Error("failed to unmarshal the "+name, "err", err)
+		var zero T
+		return zero
+	}
+	return v
+}
+
+// Save writes v as json to path
+func Save(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return Write(path, data)
+}
+
+// Write replaces the file at path with data, through a temporary file so a crash never leaves half of it
+func Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,26 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "queries.json")
+	assert.NoError(t, Save(path, []string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, Load[[]string](path, "queries"))
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestLoad_StartsEmpty(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, Load[map[string]int](filepath.Join(dir, "missing.json"), "counts"))
+
+	// a file that is only partly valid is not half loaded
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte(`{"a":1,"b":"two"}`), 0644))
+	assert.Nil(t, Load[map[string]int](invalid, "counts"))
+}
@@ -0,0 +1,274 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Pane is where a binding is active, the bindings of the focused pane take precedence over the global ones
+type Pane string
+
+const (
+	Global        Pane = "global"
+	Sidebar       Pane = "sidebar"
+	Queue         Pane = "queue"
+	SearchResults Pane = "search-results"
+	Player        Pane = "player"
+	Album         Pane = "album"
+	Artist        Pane = "artist"
+	Explore       Pane = "explore"
+	TrackInfo     Pane = "track-info"
+	Blocklist     Pane = "blocklist"
+	Lyrics        Pane = "lyrics"
+)
+
+// Panes is the order the help lists the panes in
+var Panes = []Pane{Global, Player, Sidebar, Queue, SearchResults, Album, Artist, Explore, TrackInfo, Blocklist, Lyrics}
+
+// Action is the name a binding is configured with in the "keymap" section of config.json
+type Action string
+
+const (
+	Quit               Action = "quit"
+	Help               Action = "help"
+	NextPane           Action = "next-pane"
+	PreviousPane       Action = "previous-pane"
+	Search             Action = "search"
+	Select             Action = "select"
+	Back               Action = "back"
+	PlayPause          Action = "play-pause"
+	NextTrack          Action = "next-track"
+	PreviousTrack      Action = "previous-track"
+	LikePlaying        Action = "like-playing"
+	LikeHighlighted    Action = "like-highlighted"
+	DislikePlaying     Action = "dislike-playing"
+	DislikeHighlighted Action = "dislike-highlighted"
+	ToggleLyrics       Action = "lyrics"
+	AddToQueue         Action = "add-to-queue"
+	RemoveFromQueue    Action = "remove-from-queue"
+	RemoveDuplicates   Action = "remove-duplicates"
+	Undo               Action = "undo"
+	Redo               Action = "redo"
+	SaveQueue          Action = "save-queue"
+	ExportPlaylist     Action = "export-playlist"
+	ImportPlaylist     Action = "import-playlist"
+	ShowTrackInfo      Action = "track-info"
+	BlockArtist        Action = "block-artist"
+	BlockTrack         Action = "block-track"
+	AddToPlaylist      Action = "add-to-playlist"
+	RemoveFromPlaylist Action = "remove-from-playlist"
+	NewPlaylist        Action = "new-playlist"
+	RenamePlaylist     Action = "rename-playlist"
+	DeletePlaylist     Action = "delete-playlist"
+	Expand             Action = "expand"
+	Collapse           Action = "collapse"
+	NextTab            Action = "next-tab"
+	PreviousTab        Action = "previous-tab"
+	Play               Action = "play"
+	Shuffle            Action = "shuffle"
+	QueueAlbum         Action = "queue-album"
+	OpenArtist         Action = "open-artist"
+	OpenAlbum          Action = "open-album"
+	Follow             Action = "follow"
+	ChartsCountry      Action = "charts-country"
+	CopyURL            Action = "copy-url"
+	CopyID             Action = "copy-id"
+	StartRadio         Action = "start-radio"
+	Unblock            Action = "unblock"
+	ToggleSidebar      Action = "toggle-sidebar"
+	ToggleQueue        Action = "toggle-queue"
+	CommandPalette     Action = "command-palette"
+)
+
+// Default is a binding clispot ships with, the keys of an action can be replaced in config.json
+type Default struct {
+	Action Action
+	Pane   Pane
+	Keys   []string
+	Help   string
+}
+
+// Defaults lists every binding, an action bound in several panes shares its keys between them
+var Defaults = []Default{
+	{Quit, Global, []string{"q", "ctrl+c"}, "quit"},
+	{Help, Global, []string{"?"}, "show this help"},
+	{CommandPalette, Global, []string{":"}, "run a command"},
+	{NextPane, Global, []string{"tab"}, "focus the next pane"},
+	{PreviousPane, Global, []string{"shift+tab"}, "focus the previous pane"},
+	{Search, Global, []string{"ctrl+k"}, "search"},
+	{Select, Global, []string{"enter"}, "open or play"},
+	{Back, Global, []string{"esc"}, "back"},
+	{ToggleLyrics, Global, []string{"ctrl+l"}, "lyrics"},
+	{LikePlaying, Global, []string{"l"}, "like the playing track"},
+	{LikeHighlighted, Global, []string{"L"}, "like the highlighted track"},
+	{DislikePlaying, Global, []string{"-"}, "dislike the playing track"},
+	{DislikeHighlighted, Global, []string{"_"}, "dislike the highlighted track"},
+	{AddToQueue, Global, []string{"a"}, "add to queue"},
+	{ShowTrackInfo, Global, []string{"i"}, "track info"},
+	{Undo, Global, []string{"u"}, "undo a queue change"},
+	{Redo, Global, []string{"ctrl+r"}, "redo a queue change"},
+	{SaveQueue, Global, []string{"ctrl+s"}, "save the queue as a playlist"},
+	{ExportPlaylist, Global, []string{"ctrl+e"}, "export a playlist"},
+	{ImportPlaylist, Global, []string{"ctrl+o"}, "import a playlist"},
+	{BlockArtist, Global, []string{"B"}, "block the artist"},
+	{BlockTrack, Global, []string{"ctrl+b"}, "block the track"},
+	{AddToPlaylist, Global, []string{"+"}, "add to playlist"},
+	{RemoveFromPlaylist, Global, []string{"x"}, "remove from playlist"},
+	{NewPlaylist, Global, []string{"N"}, "new playlist"},
+	{RenamePlaylist, Global, []string{"R"}, "rename playlist"},
+	{DeletePlaylist, Global, []string{"X"}, "delete playlist"},
+	{ToggleSidebar, Global, []string{"{"}, "show or hide the sidebar"},
+	{ToggleQueue, Global, []string{"}"}, "show or hide the queue"},
+	{PlayPause, Player, []string{" "}, "play/pause"},
+	{NextTrack, Player, []string{"n"}, "next track"},
+	{PreviousTrack, Player, []string{"b"}, "previous track"},
+	{Expand, Sidebar, []string{"right"}, "expand"},
+	{Collapse, Sidebar, []string{"left"}, "collapse"},
+	{RemoveFromQueue, Queue, []string{"r"}, "remove from queue"},
+	{RemoveDuplicates, Queue, []string{"D"}, "remove duplicates"},
+	{NextTab, SearchResults, []string{"]"}, "next tab"},
+	{PreviousTab, SearchResults, []string{"["}, "previous tab"},
+	{Play, Album, []string{"p"}, "play"},
+	{Shuffle, Album, []string{"s"}, "shuffle"},
+	{QueueAlbum, Album, []string{"A"}, "queue album"},
+	{OpenArtist, Album, []string{"o"}, "open artist"},
+	{Play, Artist, []string{"p"}, "play top songs"},
+	{Shuffle, Artist, []string{"s"}, "shuffle"},
+	{Follow, Artist, []string{"f"}, "follow"},
+	{Back, Explore, []string{"esc"}, "back"},
+	{ChartsCountry, Explore, []string{"c"}, "change country"},
+	{Back, TrackInfo, []string{"esc"}, "back"},
+	{StartRadio, TrackInfo, []string{"r"}, "radio"},
+	{OpenArtist, TrackInfo, []string{"o"}, "open artist"},
+	{OpenAlbum, TrackInfo, []string{"O"}, "open album"},
+	{CopyURL, TrackInfo, []string{"y"}, "copy url"},
+	{CopyID, TrackInfo, []string{"Y"}, "copy id"},
+	{Unblock, Blocklist, []string{"x", "delete"}, "unblock"},
+	{Back, Lyrics, []string{"esc"}, "back"},
+}
+
+// ListKeys move through and filter every list, a key bound to an action is not passed on to the lists so
+// they can not be bound. the other paging keys of the lists, e.g. "b" or "u", give way to the bindings
+var ListKeys = []string{"up", "down", "j", "k", "pgup", "pgdown", "home", "end", "/"}
+
+type binding struct {
+	action  Action
+	binding key.Binding
+}
+
+// KeyMap resolves the keys pressed in a pane to actions
+type KeyMap struct {
+	panes map[Pane][]binding
+}
+
+// New builds the keymap from the defaults, overrides replaces the keys of an action in every pane it is bound in,
+// an empty list of keys unbinds it
+func New(overrides map[string][]string) (*KeyMap, error) {
+	var errs []error
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.ContainsFunc(Defaults, func(d Default) bool { return string(d.Action) == name }) {
+			errs = append(errs, fmt.Errorf("unknown keymap action %q", name))
+		}
+		for _, k := range overrides[name] {
+			if slices.Contains(ListKeys, k) {
+				errs = append(errs, fmt.Errorf("%q can not be bound to %s, the lists move or filter with it", k, name))
+			}
+		}
+	}
+
+	k := &KeyMap{panes: map[Pane][]binding{}}
+	for _, d := range Defaults {
+		keys := d.Keys
+		if override, ok := overrides[string(d.Action)]; ok {
+			keys = override
+		}
+		b := key.NewBinding(key.WithKeys(keys...), key.WithHelp(displayKeys(keys), d.Help))
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+		}
+		k.panes[d.Pane] = append(k.panes[d.Pane], binding{action: d.Action, binding: b})
+	}
+	for _, pane := range Panes {
+		errs = append(errs, conflicts(pane, k.panes[pane])...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return k, nil
+}
+
+// conflicts reports keys bound to more than one action of a pane, a key of a pane shadowing a global
+// binding is not a conflict
+func conflicts(pane Pane, bindings []binding) []error {
+	var errs []error
+	owners := map[string]Action{}
+	for _, b := range bindings {
+		for _, k := range b.binding.Keys() {
+			if owner, ok := owners[k]; ok && owner != b.action {
+				errs = append(errs, fmt.Errorf("%q is bound to both %s and %s in the %s pane", k, owner, b.action, pane))
+				continue
+			}
+			owners[k] = b.action
+		}
+	}
+	return errs
+}
+
+// Lookup finds the action bound to the key, trying the panes in order and the global bindings last
+func (k *KeyMap) Lookup(pressed string, panes ...Pane) (Action, bool) {
+	for _, pane := range slices.Concat(panes, []Pane{Global}) {
+		for _, b := range k.panes[pane] {
+			if b.binding.Enabled() && slices.Contains(b.binding.Keys(), pressed) {
+				return b.action, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Key is how the first key of an action is shown in the hints, empty when the action is unbound
+func (k *KeyMap) Key(pane Pane, action Action) string {
+	for _, b := range k.panes[pane] {
+		if b.action == action && b.binding.Enabled() {
+			return displayKeys(b.binding.Keys()[:1])
+		}
+	}
+	return ""
+}
+
+// Bindings are the bindings of a pane in the order of Defaults
+func (k *KeyMap) Bindings(pane Pane) []key.Binding {
+	var bindings []key.Binding
+	for _, b := range k.panes[pane] {
+		if b.binding.Enabled() {
+			bindings = append(bindings, b.binding)
+		}
+	}
+	return bindings
+}
+
+func displayKeys(keys []string) string {
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		shown[i] = k
+	}
+	return strings.Join(shown, "/")
+}
+
+// IsText reports whether the key types a character, those keys go to a focused text input instead of the bindings
+func IsText(pressed string) bool {
+	return len([]rune(pressed)) == 1
+}
@@ -0,0 +1,99 @@
+package keymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultsHaveNoConflicts(t *testing.T) {
+	k, err := New(nil)
+	assert.NoError(t, err)
+	action, ok := k.Lookup("n", Player)
+	assert.True(t, ok)
+	assert.Equal(t, NextTrack, action)
+}
+
+func TestLookupPrefersThePane(t *testing.T) {
+	k, err := New(nil)
+	assert.NoError(t, err)
+
+	action, ok := k.Lookup("x", Blocklist)
+	assert.True(t, ok)
+	assert.Equal(t, Unblock, action)
+
+	action, ok = k.Lookup("x", Queue)
+	assert.True(t, ok)
+	assert.Equal(t, RemoveFromPlaylist, action)
+
+	_, ok = k.Lookup("n", Queue)
+	assert.False(t, ok)
+}
+
+func TestOverridesReplaceKeysInEveryPane(t *testing.T) {
+	k, err := New(map[string][]string{"play": {"P"}, "next-track": {"ctrl+n", ">"}})
+	assert.NoError(t, err)
+
+	for _, pane := range []Pane{Album, Artist} {
+		action, ok := k.Lookup("P", pane)
+		assert.True(t, ok)
+		assert.Equal(t, Play, action)
+		_, ok = k.Lookup("p", pane)
+		assert.False(t, ok)
+	}
+	action, ok := k.Lookup(">", Player)
+	assert.True(t, ok)
+	assert.Equal(t, NextTrack, action)
+	assert.Equal(t, "ctrl+n", k.Key(Player, NextTrack))
+}
+
+func TestEmptyOverrideUnbinds(t *testing.T) {
+	k, err := New(map[string][]string{"quit": {}})
+	assert.NoError(t, err)
+	_, ok := k.Lookup("q")
+	assert.False(t, ok)
+	assert.Equal(t, "", k.Key(Global, Quit))
+}
+
+func TestConflictsInAPane(t *testing.T) {
+	_, err := New(map[string][]string{"remove-duplicates": {"r"}})
+	assert.ErrorContains(t, err, `"r" is bound to both remove-from-queue and remove-duplicates in the queue pane`)
+
+	_, err = New(map[string][]string{"like-playing": {"a"}})
+	assert.ErrorContains(t, err, "in the global pane")
+}
+
+func TestShadowingAGlobalKeyIsNotAConflict(t *testing.T) {
+	_, err := New(map[string][]string{"next-track": {"a"}})
+	assert.NoError(t, err)
+}
+
+func TestListKeysCanNotBeBound(t *testing.T) {
+	_, err := New(map[string][]string{"undo": {"k"}, "search": {"/"}})
+	assert.ErrorContains(t, err, `"k" can not be bound to undo`)
+	assert.ErrorContains(t, err, `"/" can not be bound to search`)
+
+	_, err = New(map[string][]string{"undo": {"U"}})
+	assert.NoError(t, err)
+}
+
+func TestDefaultsLeaveTheListKeys(t *testing.T) {
+	for _, d := range Defaults {
+		for _, k := range d.Keys {
+			assert.NotContains(t, ListKeys, k, d.Action)
+		}
+	}
+}
+
+func TestUnknownAction(t *testing.T) {
+	_, err := New(map[string][]string{"launch-rockets": {"z"}})
+	assert.ErrorContains(t, err, `unknown keymap action "launch-rockets"`)
+}
+
+func TestIsText(t *testing.T) {
+	assert.True(t, IsText("q"))
+	assert.True(t, IsText(" "))
+	assert.True(t, IsText("é"))
+	assert.False(t, IsText("ctrl+c"))
+	assert.False(t, IsText("enter"))
+}
@@ -0,0 +1,84 @@
+package layout
+
+import "github.com/kumneger0/clispot/internal/config"
+
+// the defaults of the "layout" section of config.json
+const (
+	DefaultSidebarWidth      = 22
+	DefaultQueueWidth        = 22
+	DefaultSidebarBreakpoint = 80
+	DefaultQueueBreakpoint   = 110
+)
+
+// a docked pane takes at least and at most this percent of the width
+const (
+	minPaneWidth = 10
+	maxPaneWidth = 40
+)
+
+// Mode is how the sidebar or the queue is shown
+type Mode int
+
+const (
+	Docked Mode = iota
+	Hidden
+	// Overlay draws the pane in place of the main pane
+	Overlay
+)
+
+// Toggled are the panes the user showed or hid, a toggled pane is shown the other way than the width calls for
+type Toggled struct {
+	Sidebar bool
+	Queue   bool
+}
+
+// Panes is the width of every pane drawn, the borders are not included
+type Panes struct {
+	Sidebar      Mode
+	Queue        Mode
+	SidebarWidth int
+	QueueWidth   int
+	MainWidth    int
+}
+
+// Split shares width between the panes of a terminal columns wide, a pane that is not docked leaves its width and
+// its border to the main pane
+func Split(columns, width int, cfg config.Layout, toggled Toggled) Panes {
+	panes := Panes{
+		Sidebar:      mode(columns >= withDefault(cfg.SidebarBreakpoint, DefaultSidebarBreakpoint), toggled.Sidebar),
+		Queue:        mode(columns >= withDefault(cfg.QueueBreakpoint, DefaultQueueBreakpoint), toggled.Queue),
+		SidebarWidth: width * percent(cfg.SidebarWidth, DefaultSidebarWidth) / 100,
+		QueueWidth:   width * percent(cfg.QueueWidth, DefaultQueueWidth) / 100,
+	}
+	panes.MainWidth = width - panes.SidebarWidth - panes.QueueWidth
+	if panes.Sidebar != Docked {
+		panes.MainWidth += panes.SidebarWidth + 2
+		panes.SidebarWidth = 0
+	}
+	if panes.Queue != Docked {
+		panes.MainWidth += panes.QueueWidth + 2
+		panes.QueueWidth = 0
+	}
+	return panes
+}
+
+func mode(fits, toggled bool) Mode {
+	switch {
+	case fits && !toggled:
+		return Docked
+	case !fits && toggled:
+		return Overlay
+	}
+	return Hidden
+}
+
+func withDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func percent(value, fallback int) int {
+	return min(max(withDefault(value, fallback), minPaneWidth), maxPaneWidth)
+}
@@ -0,0 +1,48 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDocksBothPanesWhenWide(t *testing.T) {
+	panes := Split(124, 120, config.Layout{}, Toggled{})
+	assert.Equal(t, Panes{Sidebar: Docked, Queue: Docked, SidebarWidth: 26, QueueWidth: 26, MainWidth: 68}, panes)
+}
+
+func TestSplitFoldsThePanesBelowTheirBreakpoints(t *testing.T) {
+	panes := Split(100, 96, config.Layout{}, Toggled{})
+	assert.Equal(t, Docked, panes.Sidebar)
+	assert.Equal(t, Hidden, panes.Queue)
+	assert.Equal(t, 0, panes.QueueWidth)
+	// the queue leaves its width and its border to the main pane
+	assert.Equal(t, 96-21+2, panes.MainWidth)
+
+	panes = Split(70, 66, config.Layout{}, Toggled{})
+	assert.Equal(t, Hidden, panes.Sidebar)
+	assert.Equal(t, Hidden, panes.Queue)
+	assert.Equal(t, 66+4, panes.MainWidth)
+}
+
+func TestToggledFlipsThePanes(t *testing.T) {
+	panes := Split(124, 120, config.Layout{}, Toggled{Sidebar: true})
+	assert.Equal(t, Hidden, panes.Sidebar)
+	assert.Equal(t, Docked, panes.Queue)
+
+	panes = Split(70, 66, config.Layout{}, Toggled{Queue: true})
+	assert.Equal(t, Hidden, panes.Sidebar)
+	assert.Equal(t, Overlay, panes.Queue)
+	assert.Equal(t, 66+4, panes.MainWidth)
+}
+
+func TestSplitReadsTheConfig(t *testing.T) {
+	cfg := config.Layout{SidebarWidth: 30, QueueWidth: 90, SidebarBreakpoint: 150, QueueBreakpoint: 60}
+	panes := Split(104, 100, cfg, Toggled{})
+	assert.Equal(t, Hidden, panes.Sidebar)
+	assert.Equal(t, Docked, panes.Queue)
+	// the queue is held to the widest a docked pane can be
+	assert.Equal(t, 40, panes.QueueWidth)
+	assert.Equal(t, 100-30-40+30+2, panes.MainWidth)
+}
@@ -0,0 +1,104 @@
+package listfilter
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SelectGlobal selects the item at index of Items(), Select takes an index of the filtered items so a
+// filter that hides the item is cleared
+func SelectGlobal(l *list.Model, index int) {
+	if l.FilterState() == list.Unfiltered {
+		l.Select(index)
+		return
+	}
+	for i := range l.VisibleItems() {
+		l.Select(i)
+		if l.GlobalIndex() == index {
+			return
+		}
+	}
+	l.ResetFilter()
+	l.Select(index)
+}
+
+// RemoveGlobal removes the item at index of Items(), RemoveItem takes the same index for the filtered items
+// and would drop another row of them while a filter is applied. the returned command filters the items again
+func RemoveGlobal(l *list.Model, index int) tea.Cmd {
+	items := l.Items()
+	if index < 0 || index >= len(items) {
+		return nil
+	}
+	return l.SetItems(slices.Delete(slices.Clone(items), index, index+1))
+}
+
+// SplitMatches splits the rune indexes the filter matched in the filter value of an item between the
+// title and the subtitle of its row, the title has to start the filter value and the subtitle be part of it
+func SplitMatches(matches []int, filterValue, title, subtitle string) (titleMatches, subtitleMatches []int) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	value := []rune(filterValue)
+	prefix := 0
+	for _, r := range title {
+		if prefix >= len(value) || value[prefix] != r {
+			break
+		}
+		prefix++
+	}
+	subtitleStart, subtitleEnd := -1, -1
+	if subtitle != "" {
+		if index := strings.Index(filterValue, subtitle); index >= 0 {
+			subtitleStart = len([]rune(filterValue[:index]))
+			subtitleEnd = subtitleStart + len([]rune(subtitle))
+		}
+	}
+	for _, index := range matches {
+		switch {
+		case index < prefix:
+			titleMatches = append(titleMatches, index)
+		case index >= subtitleStart && index < subtitleEnd:
+			subtitleMatches = append(subtitleMatches, index-subtitleStart)
+		}
+	}
+	return titleMatches, subtitleMatches
+}
+
+// HighlightMatches renders text with style, underlining the runes at the matched indexes
+func HighlightMatches(text string, matches []int, style lipgloss.Style) string {
+	if len(matches) == 0 {
+		return style.Render(text)
+	}
+	matched := make(map[int]bool, len(matches))
+	for _, index := range matches {
+		matched[index] = true
+	}
+	match := style.Underline(true).Bold(true)
+	var b strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			b.WriteString(match.Render(string(run)))
+		} else {
+			b.WriteString(style.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package listfilter
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+type item string
+
+func (i item) FilterValue() string { return string(i) }
+
+func newFilteredList(filter string) list.Model {
+	l := list.New([]list.Item{item("apple"), item("banana"), item("cherry"), item("blueberry")}, list.NewDefaultDelegate(), 20, 20)
+	l.SetFilterText(filter)
+	return l
+}
+
+func TestSelectGlobalSelectsTheVisibleItem(t *testing.T) {
+	l := newFilteredList("b")
+	SelectGlobal(&l, 3)
+	assert.Equal(t, list.FilterApplied, l.FilterState())
+	assert.Equal(t, item("blueberry"), l.SelectedItem())
+}
+
+func TestSelectGlobalClearsAFilterHidingTheItem(t *testing.T) {
+	l := newFilteredList("b")
+	SelectGlobal(&l, 2)
+	assert.Equal(t, list.Unfiltered, l.FilterState())
+	assert.Equal(t, item("cherry"), l.SelectedItem())
+}
+
+func TestRemoveGlobalRemovesTheItemWhileFiltered(t *testing.T) {
+	l := newFilteredList("b")
+	SelectGlobal(&l, 3)
+
+	cmd := RemoveGlobal(&l, l.GlobalIndex())
+	assert.NotNil(t, cmd)
+	l, _ = l.Update(cmd())
+
+	assert.Equal(t, []list.Item{item("apple"), item("banana"), item("cherry")}, l.Items())
+	assert.Equal(t, []list.Item{item("banana")}, l.VisibleItems())
+	assert.Nil(t, RemoveGlobal(&l, 3))
+}
+
+func TestSplitMatches(t *testing.T) {
+	// the filter value of a track row is its name followed by its artists
+	titleMatches, subtitleMatches := SplitMatches([]int{0, 1, 6, 7, 8}, "Hello Adele", "Hello", "Adele")
+	assert.Equal(t, []int{0, 1}, titleMatches)
+	assert.Equal(t, []int{0, 1, 2}, subtitleMatches)
+
+	titleMatches, subtitleMatches = SplitMatches(nil, "Hello Adele", "Hello", "Adele")
+	assert.Nil(t, titleMatches)
+	assert.Nil(t, subtitleMatches)
+
+	// runes, not bytes, are counted
+	titleMatches, subtitleMatches = SplitMatches([]int{1, 4}, "Café Noir", "Café", "Noir")
+	assert.Equal(t, []int{1}, titleMatches)
+	assert.Nil(t, subtitleMatches)
+}
+
+func TestHighlightMatches(t *testing.T) {
+	style := lipgloss.NewStyle()
+	assert.Equal(t, style.Render("abc"), HighlightMatches("abc", nil, style))
+
+	match := style.Underline(true).Bold(true)
+	want := match.Render("ab") + style.Render("c") + match.Render("é")
+	assert.Equal(t, want, HighlightMatches("abcé", []int{0, 1, 3}, style))
+}
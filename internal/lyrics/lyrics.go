@@ -0,0 +1,158 @@
+package lyrics
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/config"
+)
+
+type Line struct {
+	Start time.Duration
+	Text  string
+}
+
+type Lyrics struct {
+	Lines []Line
+	// the lines have start times, so the current one can follow the playback position
+	Synced bool
+	Source string
+}
+
+// DefaultDir is where local .lrc files are looked up, set "lyrics-dir" in config.json to use another one
+func DefaultDir(goos string) string {
+	return filepath.Join(config.GetConfigDir(goos), "lyrics")
+}
+
+var (
+	timestampPattern = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	tagPattern       = regexp.MustCompile(`^\[([a-zA-Z]+):(.*)\]$`)
+)
+
+// ParseLRC parses the LRC format, lines can carry several timestamps and text without any timestamp
+// results in unsynced lyrics
+func ParseLRC(data string) Lyrics {
+	var lyrics Lyrics
+	var plain []string
+	var offset time.Duration
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var starts []time.Duration
+		for {
+			match := timestampPattern.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			starts = append(starts, parseTimestamp(match[1], match[2], match[3]))
+			line = line[len(match[0]):]
+		}
+		text := strings.TrimSpace(line)
+		if len(starts) == 0 {
+			if tag := tagPattern.FindStringSubmatch(text); tag != nil {
+				if strings.EqualFold(tag[1], "offset") {
+					// a positive offset shows the lines earlier
+					ms, _ := strconv.Atoi(strings.TrimSpace(tag[2]))
+					offset = time.Duration(ms) * time.Millisecond
+				}
+				continue
+			}
+			plain = append(plain, text)
+			continue
+		}
+		for _, start := range starts {
+			lyrics.Lines = append(lyrics.Lines, Line{Start: start, Text: text})
+		}
+	}
+	if len(lyrics.Lines) == 0 {
+		return FromText(strings.Join(plain, "\n"))
+	}
+	for i := range lyrics.Lines {
+		lyrics.Lines[i].Start = max(lyrics.Lines[i].Start-offset, 0)
+	}
+	sort.SliceStable(lyrics.Lines, func(i, j int) bool {
+		return lyrics.Lines[i].Start < lyrics.Lines[j].Start
+	})
+	lyrics.Synced = true
+	return lyrics
+}
+
+func parseTimestamp(minutes, seconds, fraction string) time.Duration {
+	m, _ := strconv.Atoi(minutes)
+	s, _ := strconv.Atoi(seconds)
+	d := time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if fraction != "" {
+		// "5" is half a second, "05" and "050" are 50ms
+		f, _ := strconv.Atoi(fraction)
+		for i := len(fraction); i < 3; i++ {
+			f *= 10
+		}
+		d += time.Duration(f) * time.Millisecond
+	}
+	return d
+}
+
+// FromText splits lyrics without timestamps into lines, trimming the blank lines around them
+func FromText(text string) Lyrics {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n ")
+	if text == "" {
+		return Lyrics{}
+	}
+	var lyrics Lyrics
+	for _, line := range strings.Split(text, "\n") {
+		lyrics.Lines = append(lyrics.Lines, Line{Text: strings.TrimSpace(line)})
+	}
+	return lyrics
+}
+
+// LineAt is the index of the line sung at position, -1 before the first line or when the lyrics are not synced
+func (l Lyrics) LineAt(position time.Duration) int {
+	if !l.Synced {
+		return -1
+	}
+	return sort.Search(len(l.Lines), func(i int) bool {
+		return l.Lines[i].Start > position
+	}) - 1
+}
+
+// fileNameReplacer drops the characters that cannot be part of a file name on any platform
+var fileNameReplacer = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_")
+
+// LocalPaths lists the files looked up in dir for a track, the most specific first
+func LocalPaths(dir, videoID, artist, title string) []string {
+	var paths []string
+	if videoID != "" {
+		paths = append(paths, filepath.Join(dir, fileNameReplacer.Replace(videoID)+".lrc"))
+	}
+	if artist != "" && title != "" {
+		paths = append(paths, filepath.Join(dir, fileNameReplacer.Replace(artist+" - "+title)+".lrc"))
+	}
+	if title != "" {
+		paths = append(paths, filepath.Join(dir, fileNameReplacer.Replace(title)+".lrc"))
+	}
+	return paths
+}
+
+// FindLocal loads the first .lrc file of LocalPaths that exists, local files take precedence over
+// the lyrics of youtube music
+func FindLocal(dir, videoID, artist, title string) (Lyrics, bool, error) {
+	for _, path := range LocalPaths(dir, videoID, artist, title) {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return Lyrics{}, false, err
+		}
+		lyrics := ParseLRC(string(data))
+		lyrics.Source = "Source: " + filepath.Base(path)
+		return lyrics, true, nil
+	}
+	return Lyrics{}, false, nil
+}
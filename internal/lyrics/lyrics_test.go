@@ -0,0 +1,67 @@
+package lyrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLRC_SortsLinesAndExpandsRepeatedTimestamps(t *testing.T) {
+	lyrics := ParseLRC("[ar:Someone]\n[ti:Song]\n[00:12.50]first\n[00:05]intro\n[01:02.05][00:20.123]chorus\n")
+
+	assert.True(t, lyrics.Synced)
+	assert.Equal(t, []Line{
+		{Start: 5 * time.Second, Text: "intro"},
+		{Start: 12*time.Second + 500*time.Millisecond, Text: "first"},
+		{Start: 20*time.Second + 123*time.Millisecond, Text: "chorus"},
+		{Start: time.Minute + 2*time.Second + 50*time.Millisecond, Text: "chorus"},
+	}, lyrics.Lines)
+}
+
+func TestParseLRC_AppliesOffset(t *testing.T) {
+	lyrics := ParseLRC("[offset:+500]\n[00:01.00]a\n[00:00.20]b\n")
+
+	assert.Equal(t, 500*time.Millisecond, lyrics.Lines[1].Start)
+	assert.Equal(t, time.Duration(0), lyrics.Lines[0].Start)
+}
+
+func TestParseLRC_WithoutTimestampsIsUnsynced(t *testing.T) {
+	lyrics := ParseLRC("\nfirst line\nsecond line\n\n")
+
+	assert.False(t, lyrics.Synced)
+	assert.Equal(t, []Line{{Text: "first line"}, {Text: "second line"}}, lyrics.Lines)
+	assert.Equal(t, -1, lyrics.LineAt(time.Minute))
+}
+
+func TestLyrics_LineAt(t *testing.T) {
+	lyrics := ParseLRC("[00:05]a\n[00:10]b\n[00:15]c\n")
+
+	assert.Equal(t, -1, lyrics.LineAt(2*time.Second))
+	assert.Equal(t, 0, lyrics.LineAt(5*time.Second))
+	assert.Equal(t, 1, lyrics.LineAt(14*time.Second))
+	assert.Equal(t, 2, lyrics.LineAt(time.Hour))
+}
+
+func TestFindLocal_PrefersTheVideoID(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "AC_DC - Back In Black.lrc"), []byte("[00:01]by name"), 0644))
+
+	lyrics, ok, err := FindLocal(dir, "abc", "AC/DC", "Back In Black")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "by name", lyrics.Lines[0].Text)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "abc.lrc"), []byte("[00:01]by id"), 0644))
+	lyrics, ok, err = FindLocal(dir, "abc", "AC/DC", "Back In Black")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "by id", lyrics.Lines[0].Text)
+	assert.Equal(t, "Source: abc.lrc", lyrics.Source)
+
+	_, ok, err = FindLocal(dir, "other", "", "Unknown")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,186 @@
+package palette
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// Command is what a line typed after ":" runs, every action of the keymap is a command of the same name
+type Command struct {
+	Name string
+	// Usage are the arguments the command takes, empty when it takes none
+	Usage string
+	Help  string
+	// Action is the action of the keymap the command runs when it is typed without arguments
+	Action keymap.Action
+	// Optional commands with a Usage also run without arguments
+	Optional bool
+	// Values complete the argument
+	Values []string
+}
+
+// the commands that take arguments
+var withArgs = []Command{
+	{Name: "queue", Usage: "clear|dedupe", Help: "clear the queue or remove its duplicates", Values: []string{"clear", "dedupe"}},
+	{Name: "seek", Usage: "[+|-]1:30", Help: "jump to a position of the playing track"},
+	{Name: "vol", Usage: "[+|-]0-100", Help: "set the volume"},
+	{Name: string(keymap.SaveQueue), Usage: "[name]", Help: "save the queue as a new playlist", Action: keymap.SaveQueue, Optional: true},
+	{Name: "theme", Usage: "<name>", Help: "switch the theme"},
+	{Name: "sleep", Usage: "[30m|off]", Help: "pause the playback after a while", Optional: true, Values: []string{"off"}},
+	{Name: string(keymap.Search), Usage: "[artists:|albums:|songs:|playlists:|videos:]query", Help: "search",
+		Action: keymap.Search, Optional: true, Values: []string{"artists:", "albums:", "songs:", "playlists:", "videos:"}},
+}
+
+// Commands is every command sorted by name
+func Commands() []Command {
+	byName := map[string]Command{}
+	for _, binding := range keymap.Defaults {
+		// the first help of an action bound in several panes is the most general one
+		if _, ok := byName[string(binding.Action)]; !ok {
+			byName[string(binding.Action)] = Command{Name: string(binding.Action), Help: binding.Help, Action: binding.Action}
+		}
+	}
+	for _, command := range withArgs {
+		byName[command.Name] = command
+	}
+	commands := make([]Command, 0, len(byName))
+	for _, command := range byName {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands
+}
+
+// Lookup finds the command by its name
+func Lookup(name string) (Command, bool) {
+	for _, command := range Commands() {
+		if command.Name == name {
+			return command, true
+		}
+	}
+	return Command{}, false
+}
+
+// Call is a parsed command line
+type Call struct {
+	Command Command
+	Args    string
+}
+
+// Parse reads a command line, the ":" in front of it is optional
+func Parse(line string) (Call, error) {
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), ":"))
+	if line == "" {
+		return Call{}, errors.New("no command given")
+	}
+	name, args, _ := strings.Cut(line, " ")
+	args = strings.TrimSpace(args)
+	command, ok := Lookup(name)
+	if !ok {
+		return Call{}, fmt.Errorf("unknown command %q", name)
+	}
+	switch {
+	case command.Usage == "" && args != "":
+		return Call{}, fmt.Errorf("%s takes no arguments", name)
+	case command.Usage != "" && !command.Optional && args == "":
+		return Call{}, fmt.Errorf("usage: %s %s", name, command.Usage)
+	}
+	return Call{Command: command, Args: args}, nil
+}
+
+// Suggestions are the lines the command line completes to, values adds the argument values only known at runtime
+// by the name of their command, e.g. the themes
+func Suggestions(values map[string][]string) []string {
+	var suggestions []string
+	for _, command := range Commands() {
+		if command.Usage == "" {
+			suggestions = append(suggestions, command.Name)
+			continue
+		}
+		suggestions = append(suggestions, command.Name+" ")
+		for _, value := range slices.Concat(command.Values, values[command.Name]) {
+			suggestions = append(suggestions, command.Name+" "+value)
+		}
+	}
+	return suggestions
+}
+
+// ParsePosition reads the argument of seek, "1:30", "1:02:03" or seconds, a "+" or "-" in front moves from current
+func ParsePosition(arg string, current time.Duration) (time.Duration, error) {
+	sign := 0
+	switch {
+	case strings.HasPrefix(arg, "+"):
+		sign = 1
+	case strings.HasPrefix(arg, "-"):
+		sign = -1
+	}
+	if sign != 0 {
+		arg = arg[1:]
+	}
+	parts := strings.Split(arg, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid position %q, expected 1:30 or seconds", arg)
+	}
+	var position time.Duration
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid position %q, expected 1:30 or seconds", arg)
+		}
+		position = position*60 + time.Duration(n)*time.Second
+	}
+	if sign != 0 {
+		return current + time.Duration(sign)*position, nil
+	}
+	return position, nil
+}
+
+// ParseVolume reads the argument of vol, a percent held to 0-100, a "+" or "-" in front changes current by it
+func ParseVolume(arg string, current int) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSuffix(arg, "%"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid volume %q, expected a percent", arg)
+	}
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		n += current
+	}
+	return min(max(n, 0), 100), nil
+}
+
+// ParseSleep reads the argument of sleep, a duration like "30m" or minutes, zero for "off"
+func ParseSleep(arg string) (time.Duration, error) {
+	if strings.EqualFold(arg, "off") {
+		return 0, nil
+	}
+	if minutes, err := strconv.Atoi(arg); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected e.g. 30m, 1h or off", arg)
+	}
+	return d, nil
+}
+
+// ParseSearch reads the argument of search, a "artist:" like prefix opens the results in that tab
+func ParseSearch(arg string) (types.SearchTab, string) {
+	prefix, query, ok := strings.Cut(arg, ":")
+	if !ok {
+		return types.SearchAllTab, arg
+	}
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	for tab := types.SearchAllTab; tab < types.SearchTabCount; tab++ {
+		if filter := tab.Filter(); filter != "" && (prefix == filter || prefix+"s" == filter) {
+			return tab, strings.TrimSpace(query)
+		}
+	}
+	return types.SearchAllTab, arg
+}
@@ -0,0 +1,112 @@
+package palette
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEveryActionIsACommand(t *testing.T) {
+	for _, binding := range keymap.Defaults {
+		command, ok := Lookup(string(binding.Action))
+		assert.True(t, ok, binding.Action)
+		assert.Equal(t, binding.Action, command.Action)
+	}
+}
+
+func TestParse(t *testing.T) {
+	call, err := Parse(":seek  1:30 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "seek", call.Command.Name)
+	assert.Equal(t, "1:30", call.Args)
+
+	call, err = Parse("next-track")
+	assert.NoError(t, err)
+	assert.Equal(t, keymap.NextTrack, call.Command.Action)
+
+	call, err = Parse("save-queue")
+	assert.NoError(t, err)
+	assert.Equal(t, keymap.SaveQueue, call.Command.Action)
+	assert.Empty(t, call.Args)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(":")
+	assert.Error(t, err)
+	_, err = Parse("rewind 10")
+	assert.EqualError(t, err, `unknown command "rewind"`)
+	_, err = Parse("next-track 2")
+	assert.EqualError(t, err, "next-track takes no arguments")
+	_, err = Parse("vol")
+	assert.EqualError(t, err, "usage: vol [+|-]0-100")
+}
+
+func TestSuggestions(t *testing.T) {
+	suggestions := Suggestions(map[string][]string{"theme": {"dark", "light"}})
+	assert.Contains(t, suggestions, "next-track")
+	assert.Contains(t, suggestions, "queue clear")
+	assert.Contains(t, suggestions, "seek ")
+	assert.Contains(t, suggestions, "theme light")
+	assert.NotContains(t, suggestions, "next-track ")
+}
+
+func TestParsePosition(t *testing.T) {
+	for arg, want := range map[string]time.Duration{
+		"1:30":    90 * time.Second,
+		"90":      90 * time.Second,
+		"1:02:03": time.Hour + 2*time.Minute + 3*time.Second,
+		"+10":     70 * time.Second,
+		"-0:15":   45 * time.Second,
+	} {
+		position, err := ParsePosition(arg, time.Minute)
+		assert.NoError(t, err, arg)
+		assert.Equal(t, want, position, arg)
+	}
+	for _, arg := range []string{"", "1:x", "1:2:3:4", "--5"} {
+		_, err := ParsePosition(arg, 0)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestParseVolume(t *testing.T) {
+	volume, err := ParseVolume("60", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, volume)
+	volume, err = ParseVolume("+10", 95)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, volume)
+	volume, err = ParseVolume("-20%", 50)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, volume)
+	_, err = ParseVolume("loud", 50)
+	assert.Error(t, err)
+}
+
+func TestParseSleep(t *testing.T) {
+	d, err := ParseSleep("30m")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, d)
+	d, err = ParseSleep("45")
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, d)
+	d, err = ParseSleep("OFF")
+	assert.NoError(t, err)
+	assert.Zero(t, d)
+	_, err = ParseSleep("-5m")
+	assert.Error(t, err)
+}
+
+func TestParseSearch(t *testing.T) {
+	tab, query := ParseSearch("artist:foo fighters")
+	assert.Equal(t, types.SearchArtistsTab, tab)
+	assert.Equal(t, "foo fighters", query)
+	tab, query = ParseSearch("Songs: bar")
+	assert.Equal(t, types.SearchSongsTab, tab)
+	assert.Equal(t, "bar", query)
+	tab, query = ParseSearch("re:zero")
+	assert.Equal(t, types.SearchAllTab, tab)
+	assert.Equal(t, "re:zero", query)
+}
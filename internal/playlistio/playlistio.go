@@ -0,0 +1,303 @@
+package playlistio
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+type Format string
+
+const (
+	M3U8 Format = "m3u8"
+	XSPF Format = "xspf"
+	JSON Format = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown playlist format, expected one of m3u8, xspf or json")
+
+// Entry is a single track read from a playlist file, VideoID is empty when the file did not reference a YouTube video
+type Entry struct {
+	VideoID         string
+	Title           string
+	Artist          string
+	Album           string
+	DurationSeconds int
+	// Track carries the full metadata when the file was written by clispot itself
+	Track *types.Track
+}
+
+// Query is the text used to look the entry up when it has no video ID
+func (e Entry) Query() string {
+	if e.Artist == "" {
+		return e.Title
+	}
+	return e.Artist + " - " + e.Title
+}
+
+// Name is a human readable name of the entry used in reports
+func (e Entry) Name() string {
+	if name := e.Query(); name != "" {
+		return name
+	}
+	return e.VideoID
+}
+
+// JSONPlaylist is the layout of the json format, the tracks are the same objects the headless api returns
+type JSONPlaylist struct {
+	Name   string                       `json:"name"`
+	Tracks []*types.PlaylistTrackObject `json:"tracks"`
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "m3u8", "m3u":
+		return M3U8, nil
+	case "xspf":
+		return XSPF, nil
+	case "json":
+		return JSON, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(filepath.Ext(path))
+}
+
+func TrackURL(videoID string) string {
+	return "https://music.youtube.com/watch?v=" + videoID
+}
+
+var videoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// VideoIDFromLocation extracts a YouTube video ID from a url (youtube.com, music.youtube.com, youtu.be) or a bare ID
+func VideoIDFromLocation(location string) string {
+	location = strings.TrimSpace(location)
+	if videoIDPattern.MatchString(location) {
+		return location
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	switch host {
+	case "youtube.com", "music.youtube.com", "m.youtube.com":
+		if id := u.Query().Get("v"); videoIDPattern.MatchString(id) {
+			return id
+		}
+	case "youtu.be":
+		if id := strings.Trim(u.Path, "/"); videoIDPattern.MatchString(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+func Encode(w io.Writer, format Format, name string, tracks []*types.PlaylistTrackObject) error {
+	switch format {
+	case M3U8:
+		return encodeM3U8(w, name, tracks)
+	case XSPF:
+		return encodeXSPF(w, name, tracks)
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(JSONPlaylist{Name: name, Tracks: tracks})
+	}
+	return ErrUnknownFormat
+}
+
+func Decode(r io.Reader, format Format) ([]Entry, error) {
+	switch format {
+	case M3U8:
+		return decodeM3U8(r)
+	case XSPF:
+		return decodeXSPF(r)
+	case JSON:
+		return decodeJSON(r)
+	}
+	return nil, ErrUnknownFormat
+}
+
+func artistNames(track types.Track) string {
+	var names []string
+	for _, artist := range track.Artists {
+		names = append(names, artist.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func encodeM3U8(w io.Writer, name string, tracks []*types.PlaylistTrackObject) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#EXTM3U")
+	if name != "" {
+		fmt.Fprintf(bw, "#PLAYLIST:%s\n", name)
+	}
+	for _, item := range tracks {
+		if item == nil {
+			continue
+		}
+		track := item.Track
+		label := track.Name
+		if artists := artistNames(track); artists != "" {
+			label = artists + " - " + track.Name
+		}
+		fmt.Fprintf(bw, "#EXTINF:%d,%s\n", track.DurationMS/1000, label)
+		if track.Album.Name != "" {
+			fmt.Fprintf(bw, "#EXTALB:%s\n", track.Album.Name)
+		}
+		fmt.Fprintln(bw, TrackURL(track.ID))
+	}
+	return bw.Flush()
+}
+
+func decodeM3U8(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	var pending Entry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXTINF:"):
+			pending = Entry{}
+			info := strings.TrimPrefix(line, "#EXTINF:")
+			duration, label, _ := strings.Cut(info, ",")
+			if seconds, err := strconv.Atoi(strings.TrimSpace(duration)); err == nil && seconds > 0 {
+				pending.DurationSeconds = seconds
+			}
+			if artist, title, ok := strings.Cut(label, " - "); ok {
+				pending.Artist = strings.TrimSpace(artist)
+				pending.Title = strings.TrimSpace(title)
+			} else {
+				pending.Title = strings.TrimSpace(label)
+			}
+		case strings.HasPrefix(line, "#EXTALB:"):
+			pending.Album = strings.TrimSpace(strings.TrimPrefix(line, "#EXTALB:"))
+		case strings.HasPrefix(line, "#"):
+			continue
+		default:
+			pending.VideoID = VideoIDFromLocation(line)
+			if pending.Title == "" && pending.VideoID == "" {
+				// a local file without #EXTINF, the file name is the best guess we have
+				pending.Title = strings.TrimSuffix(filepath.Base(line), filepath.Ext(line))
+			}
+			entries = append(entries, pending)
+			pending = Entry{}
+		}
+	}
+	return entries, scanner.Err()
+}
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"playlist"`
+	Version   string      `xml:"version,attr"`
+	Namespace string      `xml:"xmlns,attr"`
+	Title     string      `xml:"title,omitempty"`
+	Tracks    []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string `xml:"location,omitempty"`
+	Identifier string `xml:"identifier,omitempty"`
+	Title      string `xml:"title,omitempty"`
+	Creator    string `xml:"creator,omitempty"`
+	Album      string `xml:"album,omitempty"`
+	Duration   int    `xml:"duration,omitempty"` // milliseconds
+}
+
+func encodeXSPF(w io.Writer, name string, tracks []*types.PlaylistTrackObject) error {
+	playlist := xspfPlaylist{
+		Version:   "1",
+		Namespace: "http://xspf.org/ns/0/",
+		Title:     name,
+	}
+	for _, item := range tracks {
+		if item == nil {
+			continue
+		}
+		playlist.Tracks = append(playlist.Tracks, xspfTrack{
+			Location:   TrackURL(item.Track.ID),
+			Identifier: item.Track.ID,
+			Title:      item.Track.Name,
+			Creator:    artistNames(item.Track),
+			Album:      item.Track.Album.Name,
+			Duration:   item.Track.DurationMS,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(playlist); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func decodeXSPF(r io.Reader) ([]Entry, error) {
+	var playlist xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&playlist); err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, track := range playlist.Tracks {
+		videoID := VideoIDFromLocation(track.Location)
+		if videoID == "" {
+			videoID = VideoIDFromLocation(track.Identifier)
+		}
+		entries = append(entries, Entry{
+			VideoID:         videoID,
+			Title:           track.Title,
+			Artist:          track.Creator,
+			Album:           track.Album,
+			DurationSeconds: track.Duration / 1000,
+		})
+	}
+	return entries, nil
+}
+
+func decodeJSON(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var playlist JSONPlaylist
+	if err := json.Unmarshal(data, &playlist); err != nil {
+		// a bare list of tracks is accepted as well
+		if arrErr := json.Unmarshal(data, &playlist.Tracks); arrErr != nil {
+			return nil, err
+		}
+	}
+	var entries []Entry
+	for _, item := range playlist.Tracks {
+		if item == nil {
+			continue
+		}
+		track := item.Track
+		entries = append(entries, Entry{
+			VideoID:         VideoIDFromLocation(track.ID),
+			Title:           track.Name,
+			Artist:          artistNames(track),
+			Album:           track.Album.Name,
+			DurationSeconds: track.DurationMS / 1000,
+			Track:           &track,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+package playlistio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kumneger0/clispot/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleTracks() []*types.PlaylistTrackObject {
+	return []*types.PlaylistTrackObject{
+		{Track: types.Track{
+			ID:         "dQw4w9WgXcQ",
+			Name:       "Never Gonna Give You Up",
+			Artists:    []types.Artist{{Name: "Rick Astley"}},
+			Album:      types.Album{Name: "Whenever You Need Somebody"},
+			DurationMS: 213000,
+		}},
+		{Track: types.Track{
+			ID:         "kJQP7kiw5Fk",
+			Name:       "Despacito",
+			Artists:    []types.Artist{{Name: "Luis Fonsi"}, {Name: "Daddy Yankee"}},
+			DurationMS: 282000,
+		}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{M3U8, XSPF, JSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, Encode(&buf, format, "mix", sampleTracks()))
+
+			entries, err := Decode(&buf, format)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 2)
+			assert.Equal(t, "dQw4w9WgXcQ", entries[0].VideoID)
+			assert.Equal(t, "Never Gonna Give You Up", entries[0].Title)
+			assert.Equal(t, "Rick Astley", entries[0].Artist)
+			assert.Equal(t, "Whenever You Need Somebody", entries[0].Album)
+			assert.Equal(t, 213, entries[0].DurationSeconds)
+			assert.Equal(t, "kJQP7kiw5Fk", entries[1].VideoID)
+			assert.Equal(t, "Luis Fonsi, Daddy Yankee", entries[1].Artist)
+		})
+	}
+}
+
+func TestDecodeM3U8_ForeignList(t *testing.T) {
+	input := strings.Join([]string{
+		"#EXTM3U",
+		"#EXTINF:245,Daft Punk - Get Lucky",
+		"/home/me/Music/get_lucky.flac",
+		"/home/me/Music/Around the World.mp3",
+		"https://youtu.be/dQw4w9WgXcQ",
+	}, "\n")
+
+	entries, err := Decode(strings.NewReader(input), M3U8)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "", entries[0].VideoID)
+	assert.Equal(t, "Daft Punk - Get Lucky", entries[0].Query())
+	assert.Equal(t, 245, entries[0].DurationSeconds)
+	assert.Equal(t, "Around the World", entries[1].Query())
+	assert.Equal(t, "dQw4w9WgXcQ", entries[2].VideoID)
+}
+
+func TestDecodeJSON_BareArray(t *testing.T) {
+	input := `[{"track":{"id":"dQw4w9WgXcQ","name":"Never Gonna Give You Up"}}]`
+
+	entries, err := Decode(strings.NewReader(input), JSON)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "dQw4w9WgXcQ", entries[0].VideoID)
+	assert.NotNil(t, entries[0].Track)
+}
+
+func TestVideoIDFromLocation(t *testing.T) {
+	assert.Equal(t, "dQw4w9WgXcQ", VideoIDFromLocation("https://music.youtube.com/watch?v=dQw4w9WgXcQ&list=RD"))
+	assert.Equal(t, "dQw4w9WgXcQ", VideoIDFromLocation("https://www.youtube.com/watch?v=dQw4w9WgXcQ"))
+	assert.Equal(t, "dQw4w9WgXcQ", VideoIDFromLocation("https://youtu.be/dQw4w9WgXcQ"))
+	assert.Equal(t, "dQw4w9WgXcQ", VideoIDFromLocation("dQw4w9WgXcQ"))
+	assert.Equal(t, "", VideoIDFromLocation("/home/me/Music/song.mp3"))
+	assert.Equal(t, "", VideoIDFromLocation("https://example.com/watch?v=dQw4w9WgXcQ"))
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := FormatFromPath("/tmp/list.M3U")
+	assert.NoError(t, err)
+	assert.Equal(t, M3U8, format)
+
+	_, err = ParseFormat("pls")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
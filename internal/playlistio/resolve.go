@@ -0,0 +1,99 @@
+package playlistio
+
+import (
+	"context"
+	"strings"
+
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// Report is the outcome of resolving the entries of an imported playlist
+type Report struct {
+	Tracks    []*types.PlaylistTrackObject
+	Unmatched []Miss
+}
+
+// Miss is an entry no track was found for
+type Miss struct {
+	Entry
+	Reason string
+}
+
+// Resolve turns entries into playable tracks, entries with a video ID are used as is and
+// everything else is looked up by "artist - title" through SearchSongs, an entry the backend fails on
+// is reported unmatched and only a cancelled ctx stops the import
+func Resolve(ctx context.Context, client musicpb.MusicServiceClient, entries []Entry) (*Report, error) {
+	report := &Report{}
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if entry.VideoID != "" {
+			track, err := trackFromEntry(ctx, client, entry)
+			if err != nil {
+				if ctx.Err() != nil {
+					return report, ctx.Err()
+				}
+				report.Unmatched = append(report.Unmatched, Miss{entry, err.Error()})
+				continue
+			}
+			report.Tracks = append(report.Tracks, &types.PlaylistTrackObject{Track: track})
+			continue
+		}
+		query := strings.TrimSpace(entry.Query())
+		if query == "" {
+			report.Unmatched = append(report.Unmatched, Miss{entry, "no title or video ID"})
+			continue
+		}
+		resp, err := client.SearchSongs(ctx, &musicpb.SearchSongsRequest{
+			Query: query,
+			Limit: 1,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			report.Unmatched = append(report.Unmatched, Miss{entry, err.Error()})
+			continue
+		}
+		if len(resp.Songs) == 0 || resp.Songs[0].VideoId == "" {
+			report.Unmatched = append(report.Unmatched, Miss{entry, "no song found"})
+			continue
+		}
+		report.Tracks = append(report.Tracks, &types.PlaylistTrackObject{
+			Track: types.MapSongToTrack(resp.Songs[0]),
+		})
+	}
+	return report, nil
+}
+
+func trackFromEntry(ctx context.Context, client musicpb.MusicServiceClient, entry Entry) (types.Track, error) {
+	if entry.Track != nil && entry.Track.Name != "" {
+		track := *entry.Track
+		track.ID = entry.VideoID
+		return track, nil
+	}
+	if entry.Title == "" {
+		// a bare url, ask the backend what it is
+		resp, err := client.GetTrack(ctx, &musicpb.GetTrackRequest{VideoId: entry.VideoID})
+		if err != nil {
+			return types.Track{}, err
+		}
+		if resp.Track != nil {
+			track := types.MapSongToTrack(resp.Track)
+			track.ID = entry.VideoID
+			return track, nil
+		}
+	}
+	track := types.Track{
+		ID:         entry.VideoID,
+		Name:       entry.Title,
+		Album:      types.Album{Name: entry.Album},
+		DurationMS: entry.DurationSeconds * 1000,
+	}
+	if entry.Artist != "" {
+		track.Artists = []types.Artist{{Name: entry.Artist}}
+	}
+	return track, nil
+}
@@ -0,0 +1,64 @@
+package playlistio
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeBackend struct {
+	musicpb.MusicServiceClient
+	songs map[string]*musicpb.Song
+}
+
+func (f *fakeBackend) GetTrack(ctx context.Context, in *musicpb.GetTrackRequest, opts ...grpc.CallOption) (*musicpb.GetTrackResponse, error) {
+	if song, ok := f.songs[in.VideoId]; ok {
+		return &musicpb.GetTrackResponse{Track: song}, nil
+	}
+	return nil, errors.New("video unavailable")
+}
+
+func (f *fakeBackend) SearchSongs(ctx context.Context, in *musicpb.SearchSongsRequest, opts ...grpc.CallOption) (*musicpb.SearchSongsResponse, error) {
+	if song, ok := f.songs[in.Query]; ok {
+		return &musicpb.SearchSongsResponse{Songs: []*musicpb.Song{song}}, nil
+	}
+	return nil, errors.New("search failed")
+}
+
+func TestResolve_ReportsFailedEntriesAndGoesOn(t *testing.T) {
+	backend := &fakeBackend{songs: map[string]*musicpb.Song{
+		"kJQP7kiw5Fk":          {VideoId: "kJQP7kiw5Fk", Title: "Despacito"},
+		"Rick Astley - Up":     {VideoId: "dQw4w9WgXcQ", Title: "Never Gonna Give You Up"},
+		"Daft Punk - One More": {},
+	}}
+	entries := []Entry{
+		{VideoID: "deleted0000"},
+		{VideoID: "kJQP7kiw5Fk"},
+		{Artist: "Nobody", Title: "Nothing"},
+		{Artist: "Rick Astley", Title: "Up"},
+		{Artist: "Daft Punk", Title: "One More"},
+	}
+
+	report, err := Resolve(context.Background(), backend, entries)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Tracks, 2)
+	assert.Equal(t, "kJQP7kiw5Fk", report.Tracks[0].Track.ID)
+	assert.Equal(t, "dQw4w9WgXcQ", report.Tracks[1].Track.ID)
+	assert.Equal(t, []Miss{
+		{entries[0], "video unavailable"},
+		{entries[2], "search failed"},
+		{entries[4], "no song found"},
+	}, report.Unmatched)
+}
+
+func TestResolve_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Resolve(ctx, &fakeBackend{}, []Entry{{VideoID: "kJQP7kiw5Fk"}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,325 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kumneger0/clispot/internal/config"
+)
+
+// ColorNames are the colours a theme sets
+var ColorNames = []string{
+	"accent",
+	"accent-dim",
+	"text",
+	"text-secondary",
+	"text-dim",
+	"border-focused",
+	"border",
+	"selected-bg",
+	"selected-fg",
+	"selected-subtitle",
+	"progress-filled",
+	"progress-empty",
+	"player-fg",
+	"liked",
+}
+
+// BorderNames are the border styles a theme can use for the panes and the separators
+var BorderNames = []string{"rounded", "normal", "thick", "double", "hidden"}
+
+// Theme is a palette and the borders drawn with it, theme files are JSON in this shape and only need the
+// fields they change
+type Theme struct {
+	Name string `json:"name"`
+	// built-in theme the file starts from, "dark" when empty
+	Extends string `json:"extends,omitempty"`
+	// border of the panes
+	Border string `json:"border"`
+	// line under the search bar and the prompts
+	Separator string `json:"separator"`
+	// hex (#RRGGBB) or 256 colour codes by colour name
+	Colors map[string]string `json:"colors"`
+	// the colours used on terminals with only 16 colours, codes 0 to 15, a colour without one is
+	// approximated from Colors
+	ANSI map[string]string `json:"ansi"`
+}
+
+var Dark = Theme{
+	Name:      "dark",
+	Border:    "rounded",
+	Separator: "normal",
+	Colors: map[string]string{
+		"accent":            "#7D56F4",
+		"accent-dim":        "#5A3DB5",
+		"text":              "#E4E4E7",
+		"text-secondary":    "#A1A1AA",
+		"text-dim":          "#71717A",
+		"border-focused":    "#7D56F4",
+		"border":            "#3F3F46",
+		"selected-bg":       "#7D56F4",
+		"selected-fg":       "#FAFAFA",
+		"selected-subtitle": "#D4D4D8",
+		"progress-filled":   "#7D56F4",
+		"progress-empty":    "#3F3F46",
+		"player-fg":         "#E4E4E7",
+		"liked":             "#F87171",
+	},
+	ANSI: map[string]string{
+		"accent":            "5",
+		"accent-dim":        "5",
+		"text":              "15",
+		"text-secondary":    "7",
+		"text-dim":          "8",
+		"border-focused":    "5",
+		"border":            "8",
+		"selected-bg":       "5",
+		"selected-fg":       "15",
+		"selected-subtitle": "7",
+		"progress-filled":   "5",
+		"progress-empty":    "8",
+		"player-fg":         "15",
+		"liked":             "9",
+	},
+}
+
+var Light = Theme{
+	Name:      "light",
+	Border:    "rounded",
+	Separator: "normal",
+	Colors: map[string]string{
+		"accent":            "#6D28D9",
+		"accent-dim":        "#8B5CF6",
+		"text":              "#18181B",
+		"text-secondary":    "#52525B",
+		"text-dim":          "#A1A1AA",
+		"border-focused":    "#6D28D9",
+		"border":            "#D4D4D8",
+		"selected-bg":       "#6D28D9",
+		"selected-fg":       "#FFFFFF",
+		"selected-subtitle": "#EDE9FE",
+		"progress-filled":   "#6D28D9",
+		"progress-empty":    "#D4D4D8",
+		"player-fg":         "#18181B",
+		"liked":             "#DC2626",
+	},
+	ANSI: map[string]string{
+		"accent":            "5",
+		"accent-dim":        "5",
+		"text":              "0",
+		"text-secondary":    "8",
+		"text-dim":          "8",
+		"border-focused":    "5",
+		"border":            "7",
+		"selected-bg":       "5",
+		"selected-fg":       "15",
+		"selected-subtitle": "15",
+		"progress-filled":   "5",
+		"progress-empty":    "7",
+		"player-fg":         "0",
+		"liked":             "1",
+	},
+}
+
+// HighContrast keeps to black, white and yellow and draws thick borders
+var HighContrast = Theme{
+	Name:      "high-contrast",
+	Border:    "thick",
+	Separator: "thick",
+	Colors: map[string]string{
+		"accent":            "#FFFF00",
+		"accent-dim":        "#FFD700",
+		"text":              "#FFFFFF",
+		"text-secondary":    "#FFFFFF",
+		"text-dim":          "#C0C0C0",
+		"border-focused":    "#FFFF00",
+		"border":            "#FFFFFF",
+		"selected-bg":       "#FFFF00",
+		"selected-fg":       "#000000",
+		"selected-subtitle": "#000000",
+		"progress-filled":   "#FFFF00",
+		"progress-empty":    "#808080",
+		"player-fg":         "#FFFFFF",
+		"liked":             "#FF5555",
+	},
+	ANSI: map[string]string{
+		"accent":            "11",
+		"accent-dim":        "11",
+		"text":              "15",
+		"text-secondary":    "15",
+		"text-dim":          "7",
+		"border-focused":    "11",
+		"border":            "15",
+		"selected-bg":       "11",
+		"selected-fg":       "0",
+		"selected-subtitle": "0",
+		"progress-filled":   "11",
+		"progress-empty":    "7",
+		"player-fg":         "15",
+		"liked":             "9",
+	},
+}
+
+var builtins = []Theme{Dark, Light, HighContrast}
+
+// Builtin is the built-in theme with the name
+func Builtin(name string) (Theme, bool) {
+	for _, t := range builtins {
+		if strings.EqualFold(t.Name, name) {
+			return t.clone(), true
+		}
+	}
+	return Theme{}, false
+}
+
+// DefaultDir is where theme files are looked up by name, "<name>.json"
+func DefaultDir(goos string) string {
+	return filepath.Join(config.GetConfigDir(goos), "themes")
+}
+
+// Names lists the built-in themes and the theme files of dir
+func Names(dir string) []string {
+	var names []string
+	for _, t := range builtins {
+		names = append(names, t.Name)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if ok && !entry.IsDir() && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Load finds the theme by name, a file in dir takes precedence over a built-in theme of the same name, then
+// applies overrides, a JSON object shaped like a theme file
+func Load(name, dir string, overrides []byte) (Theme, error) {
+	if name == "" {
+		name = Dark.Name
+	}
+	var t Theme
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	switch {
+	case err == nil:
+		t, err = FromJSON(data)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme file %s: %w", name+".json", err)
+		}
+	case os.IsNotExist(err):
+		builtin, ok := Builtin(name)
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme %q, expected one of %s", name, strings.Join(Names(dir), ", "))
+		}
+		t = builtin
+	default:
+		return Theme{}, err
+	}
+	if len(bytes.TrimSpace(overrides)) == 0 {
+		return t, nil
+	}
+	t, err = t.apply(overrides)
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme-overrides: %w", err)
+	}
+	return t, nil
+}
+
+// FromJSON parses a theme file on top of the theme it extends
+func FromJSON(data []byte) (Theme, error) {
+	var head struct {
+		Extends string `json:"extends"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return Theme{}, err
+	}
+	base := Dark.clone()
+	if head.Extends != "" {
+		var ok bool
+		base, ok = Builtin(head.Extends)
+		if !ok {
+			return Theme{}, fmt.Errorf("cannot extend unknown theme %q", head.Extends)
+		}
+	}
+	return base.apply(data)
+}
+
+// apply sets the fields of data on a copy of the theme, a colour that is set without its 16 colour fallback
+// drops the fallback of the base theme so it is approximated from the new colour
+func (t Theme) apply(data []byte) (Theme, error) {
+	var override Theme
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&override); err != nil {
+		return Theme{}, err
+	}
+	result := t.clone()
+	if override.Name != "" {
+		result.Name = override.Name
+	}
+	if override.Border != "" {
+		result.Border = override.Border
+	}
+	if override.Separator != "" {
+		result.Separator = override.Separator
+	}
+	for name, color := range override.Colors {
+		result.Colors[name] = color
+		if _, ok := override.ANSI[name]; !ok {
+			delete(result.ANSI, name)
+		}
+	}
+	for name, color := range override.ANSI {
+		result.ANSI[name] = color
+	}
+	return result, result.validate()
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func (t Theme) validate() error {
+	for _, border := range []string{t.Border, t.Separator} {
+		if !slices.Contains(BorderNames, border) {
+			return fmt.Errorf("unknown border %q, expected one of %s", border, strings.Join(BorderNames, ", "))
+		}
+	}
+	for name, color := range t.Colors {
+		if !slices.Contains(ColorNames, name) {
+			return fmt.Errorf("unknown colour %q", name)
+		}
+		if code, err := strconv.Atoi(color); !hexColor.MatchString(color) && (err != nil || code < 0 || code > 255) {
+			return fmt.Errorf("colour %s: %q is neither #RRGGBB nor a code from 0 to 255", name, color)
+		}
+	}
+	for name, color := range t.ANSI {
+		if !slices.Contains(ColorNames, name) {
+			return fmt.Errorf("unknown colour %q", name)
+		}
+		if code, err := strconv.Atoi(color); err != nil || code < 0 || code > 15 {
+			return fmt.Errorf("ansi colour %s: %q is not a code from 0 to 15", name, color)
+		}
+	}
+	return nil
+}
+
+func (t Theme) clone() Theme {
+	t.Colors = cloneMap(t.Colors)
+	t.ANSI = cloneMap(t.ANSI)
+	return t
+}
+
+func cloneMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,78 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinThemesAreComplete(t *testing.T) {
+	for _, builtin := range builtins {
+		assert.NoError(t, builtin.validate(), builtin.Name)
+		for _, name := range ColorNames {
+			assert.NotEmpty(t, builtin.Colors[name], builtin.Name+" "+name)
+			assert.NotEmpty(t, builtin.ANSI[name], builtin.Name+" ansi "+name)
+		}
+	}
+}
+
+func TestLoadBuiltin(t *testing.T) {
+	theme, err := Load("Light", t.TempDir(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "light", theme.Name)
+	assert.Equal(t, Light.Colors["accent"], theme.Colors["accent"])
+
+	theme, err = Load("", t.TempDir(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "dark", theme.Name)
+}
+
+func TestLoadUnknown(t *testing.T) {
+	_, err := Load("solarized", t.TempDir(), nil)
+	assert.ErrorContains(t, err, `unknown theme "solarized"`)
+}
+
+func TestLoadFileExtendsABuiltin(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"extends": "light", "border": "double", "colors": {"accent": "#FF0000"}}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "red.json"), []byte(data), 0644))
+
+	theme, err := Load("red", dir, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "double", theme.Border)
+	assert.Equal(t, "#FF0000", theme.Colors["accent"])
+	assert.Equal(t, Light.Colors["text"], theme.Colors["text"])
+	// the fallback of light's accent would not match the new colour
+	assert.NotContains(t, theme.ANSI, "accent")
+	assert.Equal(t, Light.ANSI["text"], theme.ANSI["text"])
+	assert.Contains(t, Names(dir), "red")
+}
+
+func TestOverrides(t *testing.T) {
+	theme, err := Load("dark", t.TempDir(), []byte(`{"colors": {"liked": "196"}, "ansi": {"liked": "1"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "196", theme.Colors["liked"])
+	assert.Equal(t, "1", theme.ANSI["liked"])
+	// the built-in theme is left alone
+	assert.Equal(t, "#F87171", Dark.Colors["liked"])
+}
+
+func TestInvalidThemes(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Load("dark", dir, []byte(`{"colors": {"acent": "#FFFFFF"}}`))
+	assert.ErrorContains(t, err, `unknown colour "acent"`)
+
+	_, err = Load("dark", dir, []byte(`{"colors": {"accent": "purple"}}`))
+	assert.ErrorContains(t, err, "neither #RRGGBB")
+
+	_, err = Load("dark", dir, []byte(`{"ansi": {"accent": "99"}}`))
+	assert.ErrorContains(t, err, "from 0 to 15")
+
+	_, err = Load("dark", dir, []byte(`{"border": "dotted"}`))
+	assert.ErrorContains(t, err, `unknown border "dotted"`)
+
+	_, err = Load("dark", dir, []byte(`{"colours": {}}`))
+	assert.Error(t, err)
+}
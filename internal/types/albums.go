@@ -33,6 +33,9 @@ type Track struct {
 	Explicit   bool     `json:"explicit"`
 	IsLocal    bool     `json:"is_local"`
 	URL        string   `json:"url"`
+	Liked      bool     `json:"liked"`
+	// id of the entry within a playlist, needed to remove it from the playlist
+	SetVideoID string `json:"set_video_id"`
 }
 
 type Image struct {
@@ -31,3 +31,42 @@ type UserTopItemsResponse struct {
 	Total int      `json:"total"`
 	Items []Artist `json:"items"`
 }
+
+type ArtistSection string
+
+const (
+	ArtistTopSongs ArtistSection = "Top songs"
+	ArtistAlbums   ArtistSection = "Albums"
+	ArtistSingles  ArtistSection = "Singles & EPs"
+	ArtistVideos   ArtistSection = "Videos"
+	ArtistRelated  ArtistSection = "Related artists"
+)
+
+// ArtistPageItem is a row of the artist page, a row with neither Track, Album nor Artist set is the header of its section
+type ArtistPageItem struct {
+	Section  ArtistSection
+	Track    *Track
+	Album    *Album
+	Artist   *Artist
+	Subtitle string
+}
+
+func (a ArtistPageItem) IsHeader() bool {
+	return a.Track == nil && a.Album == nil && a.Artist == nil
+}
+
+func (a ArtistPageItem) FilterValue() string {
+	return a.Title()
+}
+
+func (a ArtistPageItem) Title() string {
+	switch {
+	case a.Track != nil:
+		return a.Track.Name
+	case a.Album != nil:
+		return a.Album.Name
+	case a.Artist != nil:
+		return a.Artist.Name
+	}
+	return string(a.Section)
+}
@@ -0,0 +1,16 @@
+package types // nolint:revive
+
+// BlocklistItem is a row of the blocklist view, ID is a channel id for artists and a video id for tracks
+type BlocklistItem struct {
+	IsArtist bool
+	ID       string
+	Name     string
+}
+
+func (b BlocklistItem) FilterValue() string {
+	return b.Name
+}
+
+func (b BlocklistItem) Title() string {
+	return b.Name
+}
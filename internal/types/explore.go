@@ -0,0 +1,50 @@
+package types // nolint:revive
+
+import "fmt"
+
+type ExploreItemKind string
+
+const (
+	// a row that opens another level of the explore view, ID names what it opens
+	ExploreSection  ExploreItemKind = "section"
+	ExploreHeader   ExploreItemKind = "header"
+	ExploreAlbum    ExploreItemKind = "album"
+	ExplorePlaylist ExploreItemKind = "playlist"
+	ExploreArtist   ExploreItemKind = "artist"
+	ExploreMood     ExploreItemKind = "mood"
+	ExploreTrack    ExploreItemKind = "track"
+)
+
+// ids of the sections at the top of the explore view
+const (
+	ExploreNewReleases = "new_releases"
+	ExploreTrending    = "trending"
+	ExploreCharts      = "charts"
+	ExploreMoods       = "moods"
+)
+
+// ExploreItem is a row of the explore view, ID is a browse, playlist or channel id, or the params of a mood
+type ExploreItem struct {
+	Kind     ExploreItemKind
+	ID       string
+	Name     string
+	Subtitle string
+	Track    *Track
+	// the place on a chart, 0 when the row is not on one
+	Rank int
+}
+
+func (e ExploreItem) FilterValue() string {
+	return e.Name
+}
+
+func (e ExploreItem) Title() string {
+	if e.Rank > 0 {
+		return fmt.Sprintf("%d. %s", e.Rank, e.Name)
+	}
+	return e.Name
+}
+
+func (e ExploreItem) IsHeader() bool {
+	return e.Kind == ExploreHeader
+}
@@ -0,0 +1,43 @@
+package types // nolint:revive
+
+type LibraryGroup string
+
+const (
+	LibraryPlaylists LibraryGroup = "playlists"
+	LibraryAlbums    LibraryGroup = "albums"
+	LibraryArtists   LibraryGroup = "artists"
+)
+
+// LibraryGroupItem is a collapsible group of the library tree in the sidebar
+type LibraryGroupItem struct {
+	Group      LibraryGroup
+	Name       string
+	IsExpanded bool
+	IsLoading  bool
+	// number of loaded children, -1 while the group has not been loaded yet
+	Count int
+}
+
+func (l LibraryGroupItem) FilterValue() string {
+	return l.Name
+}
+
+func (l LibraryGroupItem) Title() string {
+	return l.Name
+}
+
+// LibraryItem is a playlist, album or artist inside a library group, an empty ID is a placeholder row like "loading…"
+type LibraryItem struct {
+	Group    LibraryGroup
+	ID       string
+	Name     string
+	Subtitle string
+}
+
+func (l LibraryItem) FilterValue() string {
+	return l.Name
+}
+
+func (l LibraryItem) Title() string {
+	return l.Name
+}
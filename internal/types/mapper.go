@@ -65,12 +65,15 @@ func MapSongToTrack(pb *musicpb.Song) Track {
 		Name:    pb.Title,
 		Artists: MapArtistsToArtists(pb.Artists),
 		Album: Album{
-			ID:   pb.AlbumId,
-			Name: pb.Album,
+			ID:     pb.AlbumId,
+			Name:   pb.Album,
+			Images: MapThumbnailsToImages(pb.Thumbnails),
 		},
 		DurationMS: int(pb.DurationSeconds * 1000),
 		Explicit:   pb.IsExplicit,
 		URL:        pb.Url,
+		Liked:      pb.Liked,
+		SetVideoID: pb.SetVideoId,
 	}
 }
 
@@ -83,8 +86,9 @@ func MapSearchResultSongToTrack(pb *musicpb.SearchResultSong) Track {
 		Name:    pb.Title,
 		Artists: MapArtistsToArtists(pb.Artists),
 		Album: Album{
-			ID:   pb.AlbumId,
-			Name: pb.Album,
+			ID:     pb.AlbumId,
+			Name:   pb.Album,
+			Images: MapThumbnailsToImages(pb.Thumbnails),
 		},
 		DurationMS: int(pb.DurationSeconds * 1000),
 		Explicit:   pb.IsExplicit,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ebitengine/oto/v3"
 	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/lyrics"
 )
 
 type NextPageURLType string
@@ -17,17 +18,37 @@ const (
 )
 
 type PaginationInfo struct {
+	// continuation token of the next page
 	Next            string
 	NextPageURLType NextPageURLType
 	NextItemID      string
 }
 
+// NewPaginationInfo returns nil when there is no next page
+func NewPaginationInfo(urlType NextPageURLType, itemID, continuation string) *PaginationInfo {
+	if continuation == "" {
+		return nil
+	}
+	return &PaginationInfo{
+		Next:            continuation,
+		NextPageURLType: urlType,
+		NextItemID:      itemID,
+	}
+}
+
 type UpdatePlaylistMsg struct {
 	Playlist          []*PlaylistTrackObject
 	Err               error
 	PaginationInfo    *PaginationInfo
 	ShouldAppendQueue bool
 	ShouldAppend      bool
+	// the continuation token the page was fetched with, pages of a list the user already left are dropped
+	Continuation string
+	// set when the tracks are the items of a playlist
+	PlaylistID    string
+	PlaylistTitle string
+	// the playlist is the user's own, only those can be edited
+	PlaylistOwned bool
 }
 
 var PlayedSecondsUpdateChan = make(chan PlayedSecondsUpdateMsg)
@@ -51,8 +72,45 @@ type DBusMessage struct {
 type SearchingMsg struct{}
 
 type SpotifySearchResultMsg struct {
-	Result *SearchResponse
-	Err    error
+	Query string
+	Tab   SearchTab
+	// the number of results of the tab that were already loaded, zero for the first page
+	Offset  int
+	HasMore bool
+	Result  *SearchResponse
+	Err     error
+}
+
+type LyricsLoadedMsg struct {
+	TrackID string
+	Lyrics  lyrics.Lyrics
+	// youtube music has no lyrics for the track and there is no local file
+	NotFound bool
+	Err      error
+}
+
+type ArtworkLoadedMsg struct {
+	URL        string
+	Cols, Rows int
+	// the image drawn for the terminal, the cells it takes are blank until it is loaded
+	Rendered string
+	Err      error
+}
+
+// SleepTimerMsg is sent when the sleep timer started with the sequence number runs out
+type SleepTimerMsg struct {
+	Seq int
+}
+
+type SearchSuggestionsDebounceMsg struct {
+	Seq   int
+	Query string
+}
+
+type SearchSuggestionsMsg struct {
+	Query       string
+	Suggestions []string
+	Err         error
 }
 
 type PythonBackendHealthResponseMsg struct {
@@ -61,16 +119,20 @@ type PythonBackendHealthResponseMsg struct {
 }
 
 type CheckUserSavedTrackResponseMsg struct {
-	Saved bool
-	Err   error
-}
-
-type LikeUnlikeTrackMsg struct {
 	TrackID string
-	Like    bool
+	Saved   bool
 	Err     error
 }
 
+type RateTrackMsg struct {
+	TrackID   string
+	TrackName string
+	Rating    Rating
+	// the rating is rolled back to it when the request fails
+	Previous Rating
+	Err      error
+}
+
 type SearchAndDownloadMusicMsg struct {
 	Player  *Player
 	VideoID string
@@ -84,8 +146,10 @@ type Player struct {
 }
 
 type ByteCounterReader struct {
-	R     io.Reader
-	total int64
+	R io.Reader
+	// seconds into the track the stream starts at, set when playback started from a seek
+	Offset float64
+	total  int64
 }
 
 func (b *ByteCounterReader) Read(p []byte) (int, error) {
@@ -106,7 +170,7 @@ func (b *ByteCounterReader) Read(p []byte) (int, error) {
 }
 
 func (b *ByteCounterReader) CurrentSeconds() float64 {
-	return float64(atomic.LoadInt64(&b.total)) / 176400.0
+	return b.Offset + float64(atomic.LoadInt64(&b.total))/176400.0
 }
 
 type HomePageResponseMsg struct {
@@ -118,7 +182,92 @@ type UpdateHomePageContentMsg struct {
 	Item HomePageSectionItem
 }
 
-type PlaylistDetailMsg struct {
-	Playlist *musicpb.GetPlaylistItemsResponse
+type RadioLoadedMsg struct {
+	// the track the radio was started from
+	Track  Track
+	Tracks []Track
+	Err    error
+}
+
+// CacheRefreshedMsg is sent once a stale cached response was fetched again in the background
+type CacheRefreshedMsg struct {
+	Method  string
+	Request any
+}
+
+type ExploreLoadedMsg struct {
+	// the explore section the items belong to, empty for the playlists of a mood
+	Section string
+	Title   string
+	Items   []ExploreItem
+	// replace the level that is shown instead of opening a new one, used when the charts country changes
+	Replace bool
+	Err     error
+}
+
+type UserPlaylistsMsg struct {
+	Playlists *musicpb.GetUserPlaylistsResponse
+	Err       error
+}
+
+type PlaylistSavedMsg struct {
+	PlaylistID string
+	Name       string
+	TrackCount int
+	Err        error
+}
+
+type PlaylistEditedMsg struct {
+	PlaylistID string
+	Title      string
+	Err        error
+}
+
+type PlaylistDeletedMsg struct {
+	PlaylistID string
+	Name       string
+	Err        error
+}
+
+type PlaylistItemsRemovedMsg struct {
+	PlaylistID string
+	Track      Track
+	Err        error
+}
+
+type PlaylistExportedMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+type PlaylistImportedMsg struct {
+	Name      string
+	Tracks    []*PlaylistTrackObject
+	Unmatched []string
+	Err       error
+}
+
+type LibraryGroupLoadedMsg struct {
+	Group LibraryGroup
+	Items []LibraryItem
+	Err   error
+}
+
+type AlbumDetailMsg struct {
+	BrowseID string
+	Album    *musicpb.GetAlbumTracksResponse
 	Err      error
 }
+
+type ArtistDetailMsg struct {
+	ChannelID string
+	Artist    *musicpb.GetArtistResponse
+	Err       error
+}
+
+type ArtistFollowMsg struct {
+	ChannelID string
+	Follow    bool
+	Err       error
+}
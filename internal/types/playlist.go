@@ -41,9 +41,24 @@ type PlaylistTrackObject struct {
 }
 
 func (playlist PlaylistTrackObject) FilterValue() string {
-	return playlist.Track.Name
+	return playlist.Track.FilterValue()
 }
 
 func (playlist PlaylistTrackObject) Title() string {
 	return playlist.Track.Name
 }
+
+// PlaylistPickerItem is a row of the playlist picker, an empty ID stands for "create a new playlist"
+type PlaylistPickerItem struct {
+	ID    string
+	Name  string
+	Count int
+}
+
+func (p PlaylistPickerItem) FilterValue() string {
+	return p.Name
+}
+
+func (p PlaylistPickerItem) Title() string {
+	return p.Name
+}
@@ -10,3 +10,34 @@ type Paging[T any] struct {
 type SearchResponse struct {
 	Items []list.Item
 }
+
+// SearchTab narrows the search results to one kind of result, every tab is a separate backend search
+type SearchTab int
+
+const (
+	SearchAllTab SearchTab = iota
+	SearchSongsTab
+	SearchAlbumsTab
+	SearchArtistsTab
+	SearchPlaylistsTab
+	SearchVideosTab
+	SearchTabCount
+)
+
+var searchTabs = [SearchTabCount]struct{ name, filter string }{
+	SearchAllTab:       {name: "All"},
+	SearchSongsTab:     {name: "Songs", filter: "songs"},
+	SearchAlbumsTab:    {name: "Albums", filter: "albums"},
+	SearchArtistsTab:   {name: "Artists", filter: "artists"},
+	SearchPlaylistsTab: {name: "Playlists", filter: "playlists"},
+	SearchVideosTab:    {name: "Videos", filter: "videos"},
+}
+
+func (t SearchTab) String() string {
+	return searchTabs[t].name
+}
+
+// Filter is the filter the backend narrows the search with, empty for the top results of every kind
+func (t SearchTab) Filter() string {
+	return searchTabs[t].filter
+}
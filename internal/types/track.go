@@ -18,3 +18,12 @@ type SavedTrack struct {
 	AddedAt string `json:"added_at"`
 	Track   Track  `json:"track"`
 }
+
+// Rating is how the user rated a track on youtube music
+type Rating int
+
+const (
+	RatingNone Rating = iota
+	RatingLike
+	RatingDislike
+)
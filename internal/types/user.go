@@ -1,5 +1,7 @@
 package types // nolint:revive
 
+import "strings"
+
 type UserTokenInfo struct {
 	AccessToken  string `json:"access_token"`
 	TokenType    string `json:"token_type"`
@@ -89,10 +91,19 @@ type SearchResultItem interface {
 }
 
 func (t Track) Title() string             { return t.Name }
-func (t Track) FilterValue() string       { return t.Name }
+func (t Track) FilterValue() string       { return strings.TrimSpace(t.Name + " " + t.artistNames()) }
 func (t Track) Kind() SearchResultType    { return SearchResultTrack }
 func (a Artist) Kind() SearchResultType   { return SearchResultArtist }
 func (p Playlist) Kind() SearchResultType { return SearchResultPlaylist }
 func (a Album) Title() string             { return a.Name }
 func (a Album) FilterValue() string       { return a.Name }
 func (a Album) Kind() SearchResultType    { return SearchResultAlbum }
+
+// artistNames joins the artists the way the rows list them, so filtering on an artist highlights the row subtitle
+func (t Track) artistNames() string {
+	names := make([]string, 0, len(t.Artists))
+	for _, artist := range t.Artists {
+		names = append(names, artist.Name)
+	}
+	return strings.Join(names, ", ")
+}
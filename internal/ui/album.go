@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// AlbumPage is the header of the album view, the tracks themselves live in SelectedPlayListItems
+type AlbumPage struct {
+	BrowseID        string
+	Title           string
+	Type            string
+	Year            string
+	Artists         []types.Artist
+	DurationSeconds int
+	Images          []types.Image
+}
+
+func (m Model) openAlbum(browseID, title string) (Model, tea.Cmd) {
+	if browseID == "" {
+		return m, nil
+	}
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: title, Icon: "◉"}}
+	m.PaginationInfo = nil
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		album, err := m.YtMusicClient.GetAlbumTracks(ctx, &musicpb.GetAlbumTracksRequest{
+			BrowseId: browseID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.AlbumDetailMsg{
+			BrowseID: browseID,
+			Album:    album,
+			Err:      err,
+		}
+	}
+	return m, tea.Batch(SendLoadingCmd(), cmd)
+}
+
+func (m Model) handleAlbumDetailMsg(msg types.AlbumDetailMsg) (Model, tea.Cmd) {
+	m.IsSearchLoading = false
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	if msg.Album == nil {
+		return m, nil
+	}
+
+	page := AlbumPage{
+		BrowseID:        msg.BrowseID,
+		Title:           msg.Album.Title,
+		Type:            msg.Album.Type,
+		Year:            msg.Album.Year,
+		Artists:         types.MapArtistsToArtists(msg.Album.Artists),
+		DurationSeconds: int(msg.Album.DurationSeconds),
+		Images:          types.MapThumbnailsToImages(msg.Album.Thumbnails),
+	}
+	var durationSeconds int
+	var items []list.Item
+	for _, song := range msg.Album.Tracks {
+		track := types.MapSongToTrack(song)
+		track.Album.ID = msg.BrowseID
+		track.Album.Name = msg.Album.Title
+		if len(track.Album.Images) == 0 {
+			track.Album.Images = page.Images
+		}
+		durationSeconds += track.DurationMS / 1000
+		items = append(items, types.PlaylistTrackObject{Track: track})
+	}
+	if page.DurationSeconds == 0 {
+		page.DurationSeconds = durationSeconds
+	}
+
+	m.Album = page
+	m.MainViewMode = AlbumMode
+	m.BreadcrumbItems = nil
+	if len(page.Artists) > 0 {
+		m.BreadcrumbItems = append(m.BreadcrumbItems, types.Breadcrumb{Name: page.Artists[0].Name, Icon: "♪"})
+	}
+	m.BreadcrumbItems = append(m.BreadcrumbItems, types.Breadcrumb{Name: page.Title, Icon: "◉"})
+	cmd := m.SelectedPlayListItems.SetItems(m.visibleItems(items))
+	m.SelectedPlayListItems.Select(0)
+	return m, tea.Batch(cmd, m.Artwork.load(artworkURL(page.Images, headerArtworkCols), headerArtworkCols, headerArtworkRows))
+}
+
+func (m *Model) albumTracks() []types.PlaylistTrackObject {
+	var tracks []types.PlaylistTrackObject
+	for _, item := range m.SelectedPlayListItems.Items() {
+		if track, ok := item.(types.PlaylistTrackObject); ok {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// playAlbum replaces the queue with the album and starts from the first track, or a random order when shuffle is set
+func (m Model) playAlbum(shuffle bool) (Model, tea.Cmd) {
+	tracks := m.albumTracks()
+	if len(tracks) == 0 || m.MusicQueueList == nil {
+		return m, nil
+	}
+	if shuffle {
+		rand.Shuffle(len(tracks), func(i, j int) {
+			tracks[i], tracks[j] = tracks[j], tracks[i]
+		})
+	}
+	var items []list.Item
+	for _, track := range tracks {
+		track.IsItFromQueue = true
+		items = append(items, track)
+	}
+	m.recordQueueChange("play album")
+	m.MusicQueueList.ResetFilter()
+	cmd := m.MusicQueueList.SetItems(items)
+	m.MusicQueueList.PaginationInfo = nil
+	m.MusicQueueList.Select(0)
+	model, playCmd := m.PlaySelectedMusic(tracks[0])
+	return model, tea.Batch(cmd, playCmd)
+}
+
+// queueAlbum appends the whole album to the end of the queue
+func (m Model) queueAlbum() (Model, tea.Cmd) {
+	if m.MusicQueueList == nil {
+		return m, nil
+	}
+	items := m.MusicQueueList.Items()
+	var added int
+	for _, track := range m.albumTracks() {
+		if shouldPreventDuplicates() && m.isInQueue(track.Track) {
+			continue
+		}
+		track.IsItFromQueue = true
+		items = append(items, track)
+		added++
+	}
+	if added == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "the album is already in the queue")
+	}
+	m.recordQueueChange("queue album")
+	cmd := m.MusicQueueList.SetItems(items)
+	return m, tea.Batch(cmd, m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("added %d tracks of %s to the queue", added, m.Album.Title)))
+}
+
+func (m Model) openAlbumArtist() (Model, tea.Cmd) {
+	if len(m.Album.Artists) == 0 || m.Album.Artists[0].ID == "" {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "this album has no artist page")
+	}
+	artist := m.Album.Artists[0]
+	return m.openArtist(artist.ID, artist.Name)
+}
+
+// handleAlbumKey handles the actions of the album view, ok is false for actions that are handled elsewhere
+func (m Model) handleAlbumKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	var model Model
+	var cmd tea.Cmd
+	switch action {
+	case keymap.Play:
+		model, cmd = m.playAlbum(false)
+	case keymap.Shuffle:
+		model, cmd = m.playAlbum(true)
+	case keymap.QueueAlbum:
+		model, cmd = m.queueAlbum()
+	case keymap.OpenArtist:
+		model, cmd = m.openAlbumArtist()
+	default:
+		return m, nil, false
+	}
+	return model, cmd, true
+}
+
+func formatLongDuration(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	if d >= time.Hour {
+		return fmt.Sprintf("%d hr %d min", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%d min %d sec", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+func renderAlbumHeader(m *Model, width int) string {
+	album := m.Album
+	var artistNames []string
+	for _, artist := range album.Artists {
+		artistNames = append(artistNames, artist.Name)
+	}
+	details := []string{}
+	if album.Type != "" {
+		details = append(details, album.Type)
+	}
+	if len(artistNames) > 0 {
+		details = append(details, strings.Join(artistNames, ", "))
+	}
+	if album.Year != "" {
+		details = append(details, album.Year)
+	}
+	details = append(details, fmt.Sprintf("%d tracks", len(m.SelectedPlayListItems.Items())))
+	if album.DurationSeconds > 0 {
+		details = append(details, formatLongDuration(album.DurationSeconds))
+	}
+
+	hints := joinHints(
+		keyHint(m, keymap.Album, keymap.Play, "play"),
+		keyHint(m, keymap.Album, keymap.Shuffle, "shuffle"),
+		keyHint(m, keymap.Album, keymap.QueueAlbum, "queue album"),
+		keyHint(m, keymap.Album, keymap.OpenArtist, "open artist"),
+	)
+
+	art := headerArtwork(m, album.Images, width)
+	if art != "" {
+		width -= headerArtworkCols + 1
+	}
+	return withHeaderArtwork(art, lipgloss.NewStyle().Width(width).Padding(1, 0, 0, 1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(album.Title),
+		dimStyle.Render(strings.Join(details, " • ")),
+		hints,
+	)))
+}
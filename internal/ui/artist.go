@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// ArtistPage is the artist view, every section of the page is a run of rows in List that starts with a header row
+type ArtistPage struct {
+	ChannelID   string
+	Name        string
+	Subscribers string
+	Description string
+	IsFollowed  bool
+	Images      []types.Image
+	List        list.Model
+}
+
+func (m Model) openArtist(channelID, name string) (Model, tea.Cmd) {
+	if channelID == "" {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "this artist has no page")
+	}
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: name, Icon: "♪"}}
+	m.PaginationInfo = nil
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		artist, err := m.YtMusicClient.GetArtist(ctx, &musicpb.GetArtistRequest{
+			ChannelId: channelID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.ArtistDetailMsg{
+			ChannelID: channelID,
+			Artist:    artist,
+			Err:       err,
+		}
+	}
+	return m, tea.Batch(SendLoadingCmd(), cmd)
+}
+
+func artistPageItems(artist *musicpb.GetArtistResponse) []list.Item {
+	var items []list.Item
+	addSongs := func(section types.ArtistSection, songs []*musicpb.Song) {
+		if len(songs) == 0 {
+			return
+		}
+		items = append(items, types.ArtistPageItem{Section: section})
+		for _, song := range songs {
+			track := types.MapSongToTrack(song)
+			var artistNames []string
+			for _, a := range track.Artists {
+				artistNames = append(artistNames, a.Name)
+			}
+			items = append(items, types.ArtistPageItem{Section: section, Track: &track, Subtitle: strings.Join(artistNames, ", ")})
+		}
+	}
+	addAlbums := func(section types.ArtistSection, albums []*musicpb.Album) {
+		if len(albums) == 0 {
+			return
+		}
+		items = append(items, types.ArtistPageItem{Section: section})
+		for _, pb := range albums {
+			album := types.MapAlbumToAlbum(pb)
+			subtitle := album.Year
+			if album.Type != "" && album.Year != "" {
+				subtitle = album.Type + " • " + album.Year
+			} else if album.Type != "" {
+				subtitle = album.Type
+			}
+			items = append(items, types.ArtistPageItem{Section: section, Album: &album, Subtitle: subtitle})
+		}
+	}
+
+	addSongs(types.ArtistTopSongs, artist.TopSongs)
+	addAlbums(types.ArtistAlbums, artist.Albums)
+	addAlbums(types.ArtistSingles, artist.Singles)
+	addSongs(types.ArtistVideos, artist.Videos)
+	if len(artist.Related) > 0 {
+		items = append(items, types.ArtistPageItem{Section: types.ArtistRelated})
+		for _, related := range artist.Related {
+			relatedArtist := types.Artist{
+				ID:     related.ChannelId,
+				Name:   related.Name,
+				Images: types.MapThumbnailsToImages(related.Thumbnails),
+			}
+			items = append(items, types.ArtistPageItem{Section: types.ArtistRelated, Artist: &relatedArtist, Subtitle: related.Subscribers})
+		}
+	}
+	return items
+}
+
+func (m Model) handleArtistDetailMsg(msg types.ArtistDetailMsg) (Model, tea.Cmd) {
+	m.IsSearchLoading = false
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	if msg.Artist == nil {
+		return m, nil
+	}
+	channelID := msg.Artist.ChannelId
+	if channelID == "" {
+		channelID = msg.ChannelID
+	}
+	artistList := list.New(m.visibleItems(artistPageItems(msg.Artist)), CustomDelegate{Model: &m}, 10, 20)
+	removeListDefaults(&artistList)
+	artistList.SetShowTitle(false)
+	// the first row is a section header
+	if len(artistList.Items()) > 1 {
+		artistList.Select(1)
+	}
+	m.Artist = ArtistPage{
+		ChannelID:   channelID,
+		Name:        msg.Artist.Name,
+		Subscribers: msg.Artist.Subscribers,
+		Description: msg.Artist.Description,
+		IsFollowed:  msg.Artist.IsFollowed,
+		Images:      types.MapThumbnailsToImages(msg.Artist.Thumbnails),
+		List:        artistList,
+	}
+	m.MainViewMode = ArtistMode
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: msg.Artist.Name, Icon: "♪"}}
+	updateDelegate(&m)
+	return m, m.Artwork.load(artworkURL(m.Artist.Images, headerArtworkCols), headerArtworkCols, headerArtworkRows)
+}
+
+// sectionTracks returns the tracks of a song section of the artist page
+func (m *Model) sectionTracks(section types.ArtistSection) []types.PlaylistTrackObject {
+	var tracks []types.PlaylistTrackObject
+	for _, item := range m.Artist.List.Items() {
+		if row, ok := item.(types.ArtistPageItem); ok && row.Section == section && row.Track != nil {
+			tracks = append(tracks, types.PlaylistTrackObject{Track: *row.Track})
+		}
+	}
+	return tracks
+}
+
+// playTracks replaces the queue with tracks and plays the one at index
+func (m Model) playTracks(tracks []types.PlaylistTrackObject, index int, label string) (Model, tea.Cmd) {
+	if len(tracks) == 0 || m.MusicQueueList == nil {
+		return m, nil
+	}
+	var items []list.Item
+	for _, track := range tracks {
+		track.IsItFromQueue = true
+		items = append(items, track)
+	}
+	m.recordQueueChange(label)
+	m.MusicQueueList.ResetFilter()
+	cmd := m.MusicQueueList.SetItems(items)
+	m.MusicQueueList.PaginationInfo = nil
+	m.MusicQueueList.Select(index)
+	model, playCmd := m.PlaySelectedMusic(tracks[index])
+	return model, tea.Batch(cmd, playCmd)
+}
+
+func (m Model) handleArtistEnter() (Model, tea.Cmd) {
+	row, ok := m.Artist.List.SelectedItem().(types.ArtistPageItem)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case row.Track != nil:
+		tracks := m.sectionTracks(row.Section)
+		for index, track := range tracks {
+			if track.Track.ID == row.Track.ID {
+				return m.playTracks(tracks, index, "replace queue")
+			}
+		}
+	case row.Album != nil:
+		return m.openAlbum(row.Album.ID, row.Album.Name)
+	case row.Artist != nil:
+		return m.openArtist(row.Artist.ID, row.Artist.Name)
+	}
+	return m, nil
+}
+
+func (m Model) playArtist(shuffle bool) (Model, tea.Cmd) {
+	tracks := m.sectionTracks(types.ArtistTopSongs)
+	if shuffle {
+		rand.Shuffle(len(tracks), func(i, j int) {
+			tracks[i], tracks[j] = tracks[j], tracks[i]
+		})
+	}
+	return m.playTracks(tracks, 0, "play artist")
+}
+
+func (m Model) toggleFollowArtist() (Model, tea.Cmd) {
+	channelID := m.Artist.ChannelID
+	if channelID == "" {
+		return m, nil
+	}
+	follow := !m.Artist.IsFollowed
+	// optimistic, rolled back when the request fails
+	m.Artist.IsFollowed = follow
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var err error
+		if follow {
+			_, err = m.YtMusicClient.FollowArtist(ctx, &musicpb.FollowArtistRequest{ChannelIds: []string{channelID}})
+		} else {
+			_, err = m.YtMusicClient.UnfollowArtist(ctx, &musicpb.UnfollowArtistRequest{ChannelIds: []string{channelID}})
+		}
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.ArtistFollowMsg{
+			ChannelID: channelID,
+			Follow:    follow,
+			Err:       err,
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) handleArtistFollowMsg(msg types.ArtistFollowMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		if m.Artist.ChannelID == msg.ChannelID {
+			m.Artist.IsFollowed = !msg.Follow
+		}
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to update the followed artists: "+msg.Err.Error())
+	}
+
+	message := "unfollowed " + m.Artist.Name
+	if msg.Follow {
+		message = "following " + m.Artist.Name
+	}
+	return m, tea.Batch(m.invalidateLibraryGroup(types.LibraryArtists), m.Alert.NewAlertCmd(bubbleup.InfoKey, message))
+}
+
+// handleArtistKey handles the actions of the artist page, ok is false for actions that are handled elsewhere
+func (m Model) handleArtistKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	var model Model
+	var cmd tea.Cmd
+	switch action {
+	case keymap.Play:
+		model, cmd = m.playArtist(false)
+	case keymap.Shuffle:
+		model, cmd = m.playArtist(true)
+	case keymap.Follow:
+		model, cmd = m.toggleFollowArtist()
+	default:
+		return m, nil, false
+	}
+	return model, cmd, true
+}
+
+func renderArtistHeader(m *Model, width int) string {
+	artist := m.Artist
+	details := []string{}
+	if artist.Subscribers != "" {
+		details = append(details, artist.Subscribers+" subscribers")
+	}
+	followLabel := "follow"
+	if artist.IsFollowed {
+		details = append(details, "✓ following")
+		followLabel = "unfollow"
+	}
+
+	hints := joinHints(
+		keyHint(m, keymap.Artist, keymap.Play, "play top songs"),
+		keyHint(m, keymap.Artist, keymap.Shuffle, "shuffle"),
+		keyHint(m, keymap.Artist, keymap.Follow, followLabel),
+	)
+
+	art := headerArtwork(m, artist.Images, width)
+	if art != "" {
+		width -= headerArtworkCols + 1
+	}
+	return withHeaderArtwork(art, lipgloss.NewStyle().Width(width).Padding(1, 0, 0, 1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(artist.Name),
+		dimStyle.Render(strings.Join(details, " • ")),
+		hints,
+	)))
+}
+
+func renderArtistPageItem(item types.ArtistPageItem) (icon, title, subtitle string) {
+	title = item.Title()
+	subtitle = item.Subtitle
+	switch {
+	case item.IsHeader():
+		icon = "▾"
+	case item.Track != nil:
+		icon = "  ♫"
+		if item.Section == types.ArtistVideos {
+			icon = "  ▶"
+		}
+	case item.Album != nil:
+		icon = "  ◉"
+	case item.Artist != nil:
+		icon = "  ♪"
+	}
+	return icon, title, subtitle
+}
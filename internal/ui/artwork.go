@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/artwork"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// the cover art takes about twice as many columns as rows to stay square
+const (
+	nowPlayingArtworkCols = 6
+	nowPlayingArtworkRows = 3
+	headerArtworkCols     = 12
+	headerArtworkRows     = 6
+	// the album and artist pages leave out the cover art when the pane is narrower
+	headerArtworkMinWidth = 60
+	// the images drawn past this many drop the one shown the longest ago
+	maxRenderedArtwork = 32
+)
+
+type artworkKey struct {
+	url        string
+	cols, rows int
+}
+
+// Artwork downloads cover art and draws it once for every size it is shown at, View only reads what was drawn
+type Artwork struct {
+	protocol artwork.Protocol
+	cache    *artwork.Cache
+	// kitty images are uploaded through the output of the program so no frame is drawn in the middle of one
+	out      *artwork.Output
+	rendered map[artworkKey]string
+	// the keys of rendered, the one shown the longest ago first
+	used    []artworkKey
+	loading map[artworkKey]bool
+	// kitty images are uploaded once with an id and drawn by the placeholders that carry it, the terminal keeps
+	// them until they are deleted
+	ids    map[artworkKey]uint32
+	nextID uint32
+}
+
+func NewArtwork(protocol artwork.Protocol, cache *artwork.Cache, out *artwork.Output) *Artwork {
+	if protocol == artwork.Auto {
+		protocol = artwork.Detect(os.Getenv)
+	}
+	return &Artwork{
+		protocol: protocol,
+		cache:    cache,
+		out:      out,
+		rendered: map[artworkKey]string{},
+		loading:  map[artworkKey]bool{},
+		ids:      map[artworkKey]uint32{},
+	}
+}
+
+func (a *Artwork) enabled() bool {
+	return a != nil && a.protocol != artwork.Off
+}
+
+// load draws the image at url in cols by rows cells, nothing is done when it was drawn or is being drawn already
+func (a *Artwork) load(url string, cols, rows int) tea.Cmd {
+	key := artworkKey{url, cols, rows}
+	if !a.enabled() || url == "" || a.loading[key] {
+		return nil
+	}
+	if _, ok := a.rendered[key]; ok {
+		a.touch(key)
+		return nil
+	}
+	a.loading[key] = true
+	a.nextID++
+	id, protocol, cache, out := a.nextID, a.protocol, a.cache, a.out
+	if protocol == artwork.Kitty {
+		a.ids[key] = id
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		msg := types.ArtworkLoadedMsg{URL: url, Cols: cols, Rows: rows}
+		img, err := cache.Get(ctx, url)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		cellWidth, cellHeight := artwork.CellSize()
+		switch protocol {
+		case artwork.Kitty:
+			chunks, err := artwork.KittyTransmit(id, img, cols, rows, cellWidth, cellHeight)
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			// the upload does not draw anything by itself
+			if err := out.WriteAll(chunks); err != nil {
+				msg.Err = err
+				return msg
+			}
+			msg.Rendered = artwork.KittyPlaceholders(id, cols, rows)
+		case artwork.Sixel:
+			msg.Rendered = artwork.SixelCells(img, cols, rows, cellWidth, cellHeight)
+		default:
+			msg.Rendered = artwork.HalfBlocks(img, cols, rows)
+		}
+		return msg
+	}
+}
+
+// loaded keeps the drawn image and drops the ones shown the longest ago past maxRenderedArtwork, except the shown
+// ones, the returned command deletes their kitty images
+func (a *Artwork) loaded(msg types.ArtworkLoadedMsg, shown []artworkKey) tea.Cmd {
+	if a == nil {
+		return nil
+	}
+	key := artworkKey{msg.URL, msg.Cols, msg.Rows}
+	delete(a.loading, key)
+	if msg.Err != nil {
+		// the cover art is only decoration, the view is drawn without it
+		slog.Error("failed to load the artwork", "url", msg.URL, "err", msg.Err)
+	}
+	a.rendered[key] = msg.Rendered
+	a.touch(key)
+
+	var deleted []string
+	for i := 0; len(a.used) > maxRenderedArtwork && i < len(a.used); {
+		evicted := a.used[i]
+		if slices.Contains(shown, evicted) {
+			i++
+			continue
+		}
+		a.used = slices.Delete(a.used, i, i+1)
+		delete(a.rendered, evicted)
+		if id, ok := a.ids[evicted]; ok {
+			deleted = append(deleted, artwork.KittyDelete(id))
+			delete(a.ids, evicted)
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	out := a.out
+	return func() tea.Msg {
+		if err := out.WriteAll(deleted); err != nil {
+			slog.Error("failed to delete the artwork", "err", err)
+		}
+		return nil
+	}
+}
+
+// touch moves key to the end of used, it is dropped last
+func (a *Artwork) touch(key artworkKey) {
+	if i := slices.Index(a.used, key); i >= 0 {
+		a.used = slices.Delete(a.used, i, i+1)
+	}
+	a.used = append(a.used, key)
+}
+
+// view is the image at url, blank cells of its size while it loads and empty when there is none to show
+func (a *Artwork) view(url string, cols, rows int) string {
+	if !a.enabled() || url == "" {
+		return ""
+	}
+	key := artworkKey{url, cols, rows}
+	if rendered, ok := a.rendered[key]; ok {
+		return rendered
+	}
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", cols)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// artworkURL picks an image big enough for cols cells, a cell is rarely wider than 20 pixels
+func artworkURL(images []types.Image, cols int) string {
+	return artwork.Pick(images, cols*20)
+}
+
+func nowPlayingArtworkURL(m *Model) string {
+	if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+		return ""
+	}
+	return artworkURL(m.SelectedTrack.Track.Track.Album.Images, nowPlayingArtworkCols)
+}
+
+// shownArtwork are the images the view draws right now, they are kept however long ago they were loaded
+func shownArtwork(m *Model) []artworkKey {
+	shown := []artworkKey{{nowPlayingArtworkURL(m), nowPlayingArtworkCols, nowPlayingArtworkRows}}
+	switch m.MainViewMode {
+	case AlbumMode:
+		shown = append(shown, artworkKey{artworkURL(m.Album.Images, headerArtworkCols), headerArtworkCols, headerArtworkRows})
+	case ArtistMode:
+		shown = append(shown, artworkKey{artworkURL(m.Artist.Images, headerArtworkCols), headerArtworkCols, headerArtworkRows})
+	}
+	return shown
+}
+
+func nowPlayingArtwork(m *Model) string {
+	return m.Artwork.view(nowPlayingArtworkURL(m), nowPlayingArtworkCols, nowPlayingArtworkRows)
+}
+
+// headerArtwork is the cover art left of the album and artist headers
+func headerArtwork(m *Model, images []types.Image, width int) string {
+	if width < headerArtworkMinWidth {
+		return ""
+	}
+	return m.Artwork.view(artworkURL(images, headerArtworkCols), headerArtworkCols, headerArtworkRows)
+}
+
+func withHeaderArtwork(art, header string) string {
+	if art == "" {
+		return header
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Padding(1, 0, 0, 1).Render(art), header)
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/filter"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/listfilter"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// shown after the title of explicit tracks when they are skipped instead of hidden
+const explicitMark = " [E]"
+
+func isBlocklistSidebarItem(item types.SidebarItem) bool {
+	return strings.ToLower(strings.TrimSpace(item.Name)) == "blocklist"
+}
+
+// hidesItem reports whether the content filter leaves a row out of the lists
+func (m *Model) hidesItem(item list.Item) bool {
+	switch item := item.(type) {
+	case types.PlaylistTrackObject:
+		return m.Filter.Hides(item.Track)
+	case types.Track:
+		return m.Filter.Hides(item)
+	case types.Artist:
+		return m.Filter.IsArtistBlocked(item)
+	case types.ArtistPageItem:
+		if item.Track != nil {
+			return m.Filter.Hides(*item.Track)
+		}
+		if item.Artist != nil {
+			return m.Filter.IsArtistBlocked(*item.Artist)
+		}
+	case types.ExploreItem:
+		if item.Track != nil {
+			return m.Filter.Hides(*item.Track)
+		}
+		if item.Kind == types.ExploreArtist {
+			return m.Filter.IsArtistBlocked(types.Artist{ID: item.ID, Name: item.Name})
+		}
+	}
+	return false
+}
+
+// visibleItems drops the rows the content filter hides, and the section headers that are left without rows
+func (m *Model) visibleItems(items []list.Item) []list.Item {
+	if m.Filter == nil {
+		return items
+	}
+	visible := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		if !m.hidesItem(item) {
+			visible = append(visible, item)
+		}
+	}
+	isHeader := func(item list.Item) bool {
+		h, ok := item.(interface{ IsHeader() bool })
+		return ok && h.IsHeader()
+	}
+	withRows := make([]list.Item, 0, len(visible))
+	for index, item := range visible {
+		if isHeader(item) && (index+1 == len(visible) || isHeader(visible[index+1])) {
+			continue
+		}
+		withRows = append(withRows, item)
+	}
+	return withRows
+}
+
+// refilterLists applies the blocklist to the lists that are already shown
+func (m *Model) refilterLists() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, l := range []*list.Model{&m.SelectedPlayListItems, &m.SearchResult, &m.Artist.List, &m.Explore.List} {
+		items := l.Items()
+		visible := m.visibleItems(items)
+		if len(visible) != len(items) {
+			cmds = append(cmds, l.SetItems(visible))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) blockHighlightedTrack() (Model, tea.Cmd) {
+	track, ok := m.highlightedTrack()
+	if !ok || track.ID == "" || m.Filter == nil {
+		return m, nil
+	}
+	if err := m.Filter.BlockTrack(filter.BlockedTrack{VideoID: track.ID, Name: track.Name}); err != nil {
+		slog.Error(err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to save the blocklist: "+err.Error())
+	}
+	return m, tea.Batch(m.refilterLists(), m.Alert.NewAlertCmd(bubbleup.InfoKey, "blocked "+track.Name))
+}
+
+// blockHighlightedArtist blocks the first artist of the highlighted track, or the artist whose page is open
+func (m Model) blockHighlightedArtist() (Model, tea.Cmd) {
+	if m.Filter == nil {
+		return m, nil
+	}
+	var artist types.Artist
+	if track, ok := m.highlightedTrack(); ok && len(track.Artists) > 0 {
+		artist = track.Artists[0]
+	} else if m.FocusedOn == MainView && m.MainViewMode == ArtistMode {
+		if row, ok := m.Artist.List.SelectedItem().(types.ArtistPageItem); ok && row.Artist != nil {
+			artist = *row.Artist
+		} else {
+			artist = types.Artist{ID: m.Artist.ChannelID, Name: m.Artist.Name}
+		}
+	}
+	if artist.ID == "" && artist.Name == "" {
+		return m, nil
+	}
+	if err := m.Filter.BlockArtist(filter.BlockedArtist{ID: artist.ID, Name: artist.Name}); err != nil {
+		slog.Error(err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to save the blocklist: "+err.Error())
+	}
+	return m, tea.Batch(m.refilterLists(), m.Alert.NewAlertCmd(bubbleup.InfoKey, "blocked "+artist.Name))
+}
+
+func (m *Model) blocklistItems() []list.Item {
+	blocklist := m.Filter.Blocklist()
+	var items []list.Item
+	for _, artist := range blocklist.Artists {
+		items = append(items, types.BlocklistItem{IsArtist: true, ID: artist.ID, Name: artist.Name})
+	}
+	for _, track := range blocklist.Tracks {
+		items = append(items, types.BlocklistItem{ID: track.VideoID, Name: track.Name})
+	}
+	return items
+}
+
+func (m Model) openBlocklist() (Model, tea.Cmd) {
+	m.Blocklist = list.New(m.blocklistItems(), CustomDelegate{Model: &m}, 10, 20)
+	removeListDefaults(&m.Blocklist)
+	m.Blocklist.SetShowTitle(false)
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Blocklist", Icon: "⊘"}}
+	m.PaginationInfo = nil
+	m.MainViewMode = BlocklistMode
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+	return m, nil
+}
+
+func (m Model) unblockSelected() (Model, tea.Cmd) {
+	item, ok := m.Blocklist.SelectedItem().(types.BlocklistItem)
+	if !ok {
+		return m, nil
+	}
+	var err error
+	if item.IsArtist {
+		err = m.Filter.UnblockArtist(filter.BlockedArtist{ID: item.ID, Name: item.Name})
+	} else {
+		err = m.Filter.UnblockTrack(item.ID)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to save the blocklist: "+err.Error())
+	}
+	return m, tea.Batch(
+		listfilter.RemoveGlobal(&m.Blocklist, m.Blocklist.GlobalIndex()),
+		m.Alert.NewAlertCmd(bubbleup.InfoKey, "unblocked "+item.Name+", it shows up again the next time a list is loaded"),
+	)
+}
+
+// handleBlocklistKey handles the actions of the blocklist view, ok is false for keys that are handled elsewhere
+func (m Model) handleBlocklistKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	switch action {
+	case keymap.Select, keymap.Unblock:
+		model, cmd := m.unblockSelected()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+func renderBlocklistHeader(m *Model, width int) string {
+	explicit := "shown"
+	switch m.Filter.Explicit() {
+	case filter.ExplicitHide:
+		explicit = "hidden"
+	case filter.ExplicitSkip:
+		explicit = "skipped"
+	}
+	onNoMatch := "stops"
+	if config.GetConfig().SkipOnNoMatch {
+		onNoMatch = "skips to the next track"
+	}
+	details := []string{
+		fmt.Sprintf("%d blocked", len(m.Blocklist.Items())),
+		"explicit tracks are " + explicit,
+		"on a filtered track playback " + onNoMatch,
+	}
+	hints := joinHints(
+		keyHint(m, keymap.Blocklist, keymap.Unblock, "unblock"),
+		keyHint(m, keymap.Global, keymap.BlockArtist, "block artist"),
+		keyHint(m, keymap.Global, keymap.BlockTrack, "block track"),
+	)
+	return lipgloss.NewStyle().Width(width).Padding(1, 0, 0, 1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Blocklist"),
+		dimStyle.Render(strings.Join(details, " • ")),
+		hints,
+	))
+}
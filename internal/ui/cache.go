@@ -0,0 +1,34 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// handleCacheRefreshedMsg loads the view that is shown again once the cached data behind it was refreshed,
+// views that are not shown pick the new data up the next time they are opened
+func (m Model) handleCacheRefreshedMsg(msg types.CacheRefreshedMsg) (Model, tea.Cmd) {
+	switch req := msg.Request.(type) {
+	case *musicpb.GetHomePageRequest:
+		if m.MainViewMode == HomePageMode && m.HomePageViewMode == HomePageSectionView {
+			return m, m.getHomePage()
+		}
+	case *musicpb.GetUserPlaylistsRequest:
+		return m, m.invalidateLibraryGroup(types.LibraryPlaylists)
+	case *musicpb.GetUserSavedAlbumsRequest:
+		return m, m.invalidateLibraryGroup(types.LibraryAlbums)
+	case *musicpb.GetFollowedArtistsRequest:
+		return m, m.invalidateLibraryGroup(types.LibraryArtists)
+	case *musicpb.GetPlaylistItemsRequest:
+		// a later page would throw away the pages the user already scrolled through
+		if req.Continuation == "" && m.isViewingPlaylist() && m.Playlist.ID == req.PlaylistId && !m.loadedMorePages {
+			return m, m.getPlaylistItems(req.PlaylistId)
+		}
+	case *musicpb.GetUserSavedTracksRequest:
+		if req.Continuation == "" && m.isViewingLikedSongs() && !m.loadedMorePages {
+			return m, m.getUserSavedTracks()
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/dedupe"
+	"github.com/kumneger0/clispot/internal/listfilter"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+func uniqueTrackItems(items []list.Item, keepID string) []list.Item {
+	var validItems []list.Item
+	var tracks []types.Track
+	for _, item := range items {
+		if track, ok := item.(types.PlaylistTrackObject); ok {
+			validItems = append(validItems, item)
+			tracks = append(tracks, track.Track)
+		}
+	}
+	var unique []list.Item
+	for _, index := range dedupe.Unique(tracks, keepID) {
+		unique = append(unique, validItems[index])
+	}
+	return unique
+}
+
+func (m *Model) isInQueue(track types.Track) bool {
+	if m.MusicQueueList == nil {
+		return false
+	}
+	set := dedupe.NewSet()
+	for _, item := range m.MusicQueueList.Items() {
+		if queued, ok := item.(types.PlaylistTrackObject); ok {
+			set.Add(queued.Track)
+		}
+	}
+	return set.Contains(track)
+}
+
+func shouldPreventDuplicates() bool {
+	return config.GetConfig().PreventDuplicates
+}
+
+func (m Model) removeQueueDuplicates() (Model, tea.Cmd) {
+	if m.MusicQueueList == nil {
+		return m, nil
+	}
+	var playingID string
+	if m.SelectedTrack != nil && m.SelectedTrack.Track != nil {
+		playingID = m.SelectedTrack.Track.Track.ID
+	}
+	items := m.MusicQueueList.Items()
+	unique := uniqueTrackItems(items, playingID)
+	removed := len(items) - len(unique)
+	if removed == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "the queue has no duplicates")
+	}
+	m.recordQueueChange("remove duplicates")
+	index := min(m.MusicQueueList.GlobalIndex(), len(unique)-1)
+	cmd := m.MusicQueueList.SetItems(unique)
+	listfilter.SelectGlobal(&m.MusicQueueList.Model, max(index, 0))
+	return m, tea.Batch(cmd, m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("removed %d duplicates from the queue", removed)))
+}
@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+type exploreLevel struct {
+	Title string
+	// the section the level was loaded for, used to reload the charts when the country changes
+	Section string
+	Items   []list.Item
+	Index   int
+}
+
+// ExplorePage is the explore view, every level that was opened is kept so esc goes back to where the user was
+type ExplorePage struct {
+	List   list.Model
+	levels []exploreLevel
+	// ISO code of the country the charts are shown for, the config default until the user picks another one
+	Country string
+}
+
+func exploreSections() []list.Item {
+	return []list.Item{
+		types.ExploreItem{Kind: types.ExploreSection, ID: types.ExploreNewReleases, Name: "New releases"},
+		types.ExploreItem{Kind: types.ExploreSection, ID: types.ExploreTrending, Name: "Trending"},
+		types.ExploreItem{Kind: types.ExploreSection, ID: types.ExploreCharts, Name: "Charts"},
+		types.ExploreItem{Kind: types.ExploreSection, ID: types.ExploreMoods, Name: "Moods & genres"},
+	}
+}
+
+func (m Model) openExplore() (Model, tea.Cmd) {
+	if m.Explore.Country == "" {
+		m.Explore.Country = strings.ToUpper(config.GetConfig().ChartsCountry)
+	}
+	m.Explore.levels = []exploreLevel{{Title: "Explore", Items: exploreSections()}}
+	m.Explore.List = list.New(exploreSections(), CustomDelegate{Model: &m}, 10, 20)
+	removeListDefaults(&m.Explore.List)
+	m.Explore.List.SetShowTitle(false)
+	m.MainViewMode = ExploreMode
+	m.FocusedOn = MainView
+	m.PaginationInfo = nil
+	m.setExploreBreadcrumbs()
+	updateDelegate(&m)
+	return m, nil
+}
+
+func (m *Model) setExploreBreadcrumbs() {
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Explore", Icon: "✦"}}
+	for _, level := range m.Explore.levels[1:] {
+		m.BreadcrumbItems = append(m.BreadcrumbItems, types.Breadcrumb{Name: level.Title})
+	}
+}
+
+func (m Model) loadExploreSection(section string, replace bool) tea.Cmd {
+	country := m.Explore.Country
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var items []types.ExploreItem
+		title := ""
+		switch section {
+		case types.ExploreNewReleases, types.ExploreTrending:
+			resp, err := m.YtMusicClient.GetExplore(ctx, &musicpb.GetExploreRequest{})
+			if err != nil {
+				slog.Error(err.Error())
+				return types.ExploreLoadedMsg{Section: section, Err: err}
+			}
+			if section == types.ExploreNewReleases {
+				title = "New releases"
+				for _, pb := range resp.NewReleases {
+					album := types.MapAlbumToAlbum(pb)
+					var artistNames []string
+					for _, artist := range album.Artists {
+						artistNames = append(artistNames, artist.Name)
+					}
+					items = append(items, types.ExploreItem{Kind: types.ExploreAlbum, ID: album.ID, Name: album.Name, Subtitle: strings.Join(artistNames, ", ")})
+				}
+				break
+			}
+			title = "Trending"
+			if resp.TrendingPlaylistId != "" {
+				items = append(items, types.ExploreItem{Kind: types.ExplorePlaylist, ID: resp.TrendingPlaylistId, Name: "Trending playlist"})
+			}
+			for _, song := range resp.Trending {
+				track := types.MapSongToTrack(song)
+				var artistNames []string
+				for _, artist := range track.Artists {
+					artistNames = append(artistNames, artist.Name)
+				}
+				items = append(items, types.ExploreItem{Kind: types.ExploreTrack, ID: track.ID, Name: track.Name, Subtitle: strings.Join(artistNames, ", "), Track: &track})
+			}
+		case types.ExploreCharts:
+			resp, err := m.YtMusicClient.GetCharts(ctx, &musicpb.GetChartsRequest{Country: country})
+			if err != nil {
+				slog.Error(err.Error())
+				return types.ExploreLoadedMsg{Section: section, Err: err}
+			}
+			title = "Charts"
+			if resp.Country != "" {
+				title = "Charts · " + resp.Country
+			}
+			if len(resp.Playlists) > 0 {
+				items = append(items, types.ExploreItem{Kind: types.ExploreHeader, Name: "Playlists"})
+				for _, playlist := range resp.Playlists {
+					items = append(items, types.ExploreItem{Kind: types.ExplorePlaylist, ID: playlist.PlaylistId, Name: playlist.Title, Subtitle: playlist.Author})
+				}
+			}
+			if len(resp.Artists) > 0 {
+				items = append(items, types.ExploreItem{Kind: types.ExploreHeader, Name: "Top artists"})
+				for _, artist := range resp.Artists {
+					items = append(items, types.ExploreItem{Kind: types.ExploreArtist, ID: artist.ChannelId, Name: artist.Name, Rank: int(artist.Rank), Subtitle: chartTrend(artist.Trend, artist.Subscribers)})
+				}
+			}
+		case types.ExploreMoods:
+			resp, err := m.YtMusicClient.GetMoodCategories(ctx, &musicpb.GetMoodCategoriesRequest{})
+			if err != nil {
+				slog.Error(err.Error())
+				return types.ExploreLoadedMsg{Section: section, Err: err}
+			}
+			title = "Moods & genres"
+			for _, moodSection := range resp.Sections {
+				items = append(items, types.ExploreItem{Kind: types.ExploreHeader, Name: moodSection.Title})
+				for _, category := range moodSection.Categories {
+					items = append(items, types.ExploreItem{Kind: types.ExploreMood, ID: category.Params, Name: category.Title})
+				}
+			}
+		}
+		return types.ExploreLoadedMsg{Section: section, Title: title, Items: items, Replace: replace}
+	}
+}
+
+func chartTrend(trend, subscribers string) string {
+	arrow := ""
+	switch trend {
+	case "up":
+		arrow = "▲"
+	case "down":
+		arrow = "▼"
+	}
+	if subscribers != "" {
+		subscribers += " subscribers"
+	}
+	return strings.TrimSpace(arrow + " " + subscribers)
+}
+
+func (m Model) loadMoodPlaylists(mood types.ExploreItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		resp, err := m.YtMusicClient.GetMoodPlaylists(ctx, &musicpb.GetMoodPlaylistsRequest{Params: mood.ID})
+		if err != nil {
+			slog.Error(err.Error())
+			return types.ExploreLoadedMsg{Err: err}
+		}
+		var items []types.ExploreItem
+		for _, playlist := range resp.Playlists {
+			items = append(items, types.ExploreItem{Kind: types.ExplorePlaylist, ID: playlist.PlaylistId, Name: playlist.Title, Subtitle: playlist.Description})
+		}
+		return types.ExploreLoadedMsg{Title: mood.Name, Items: items}
+	}
+}
+
+func (m Model) handleExploreLoadedMsg(msg types.ExploreLoadedMsg) (Model, tea.Cmd) {
+	m.IsSearchLoading = false
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	// the user left the explore view while the request was in flight
+	if m.MainViewMode != ExploreMode || len(m.Explore.levels) == 0 {
+		return m, nil
+	}
+	if len(msg.Items) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "nothing to show here yet")
+	}
+	items := make([]list.Item, 0, len(msg.Items))
+	for _, item := range msg.Items {
+		items = append(items, item)
+	}
+	items = m.visibleItems(items)
+	if len(items) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "everything here is hidden by the content filter")
+	}
+	level := exploreLevel{Title: msg.Title, Section: msg.Section, Items: items}
+	if msg.Replace && len(m.Explore.levels) > 1 {
+		m.Explore.levels[len(m.Explore.levels)-1] = level
+	} else {
+		m.Explore.levels[len(m.Explore.levels)-1].Index = m.Explore.List.Index()
+		m.Explore.levels = append(m.Explore.levels, level)
+	}
+	cmd := m.Explore.List.SetItems(items)
+	m.Explore.List.Select(0)
+	// skip the header the level starts with
+	if first, ok := items[0].(types.ExploreItem); ok && first.Kind == types.ExploreHeader && len(items) > 1 {
+		m.Explore.List.Select(1)
+	}
+	m.setExploreBreadcrumbs()
+	return m, cmd
+}
+
+// exploreBack goes back to the previous level of the explore view
+func (m Model) exploreBack() (Model, tea.Cmd) {
+	if len(m.Explore.levels) <= 1 {
+		return m, nil
+	}
+	m.Explore.levels = m.Explore.levels[:len(m.Explore.levels)-1]
+	level := m.Explore.levels[len(m.Explore.levels)-1]
+	cmd := m.Explore.List.SetItems(level.Items)
+	m.Explore.List.Select(level.Index)
+	m.setExploreBreadcrumbs()
+	return m, cmd
+}
+
+// exploreTracks returns the tracks of the level that is shown
+func (m *Model) exploreTracks() []types.PlaylistTrackObject {
+	var tracks []types.PlaylistTrackObject
+	for _, item := range m.Explore.List.Items() {
+		if row, ok := item.(types.ExploreItem); ok && row.Track != nil {
+			tracks = append(tracks, types.PlaylistTrackObject{Track: *row.Track})
+		}
+	}
+	return tracks
+}
+
+func (m Model) handleExploreEnter() (Model, tea.Cmd) {
+	item, ok := m.Explore.List.SelectedItem().(types.ExploreItem)
+	if !ok {
+		return m, nil
+	}
+	switch item.Kind {
+	case types.ExploreSection:
+		return m, tea.Batch(SendLoadingCmd(), m.loadExploreSection(item.ID, false))
+	case types.ExploreMood:
+		return m, tea.Batch(SendLoadingCmd(), m.loadMoodPlaylists(item))
+	case types.ExplorePlaylist:
+		if item.ID == "" {
+			return m, nil
+		}
+		m.BreadcrumbItems = append(m.BreadcrumbItems, types.Breadcrumb{Name: item.Name})
+		m.PaginationInfo = nil
+		return m, tea.Batch(SendLoadingCmd(), m.getPlaylistItems(item.ID))
+	case types.ExploreAlbum:
+		return m.openAlbum(item.ID, item.Name)
+	case types.ExploreArtist:
+		return m.openArtist(item.ID, item.Name)
+	case types.ExploreTrack:
+		tracks := m.exploreTracks()
+		for index, track := range tracks {
+			if track.Track.ID == item.ID {
+				return m.playTracks(tracks, index, "replace queue")
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) isViewingCharts() bool {
+	if m.MainViewMode != ExploreMode || len(m.Explore.levels) == 0 {
+		return false
+	}
+	return m.Explore.levels[len(m.Explore.levels)-1].Section == types.ExploreCharts
+}
+
+// handleExploreKey handles the actions of the explore view, ok is false for actions that are handled elsewhere
+func (m Model) handleExploreKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	switch action {
+	case keymap.Back:
+		model, cmd := m.exploreBack()
+		return model, cmd, true
+	case keymap.ChartsCountry:
+		if !m.isViewingCharts() {
+			return m, nil, true
+		}
+		model, cmd := m.openPrompt(ChartsCountryPrompt, "Charts country (ISO code, ZZ for global)", "", m.Explore.Country)
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+func (m Model) setChartsCountry(value string) (Model, tea.Cmd) {
+	country := strings.ToUpper(value)
+	if len(country) != 2 {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "use a two letter country code like US or DE")
+	}
+	m.Explore.Country = country
+	if !m.isViewingCharts() {
+		return m, nil
+	}
+	return m, tea.Batch(SendLoadingCmd(), m.loadExploreSection(types.ExploreCharts, true))
+}
+
+func renderExploreHints(m *Model) string {
+	hints := []string{keyHint(m, keymap.Explore, keymap.Back, "back")}
+	if m.isViewingCharts() {
+		hints = append(hints, keyHint(m, keymap.Explore, keymap.ChartsCountry, "change country"))
+	}
+	return lipgloss.NewStyle().Padding(0, 0, 0, 1).Render(joinHints(hints...))
+}
+
+func renderExploreItem(item types.ExploreItem) (icon, title, subtitle string) {
+	title = item.Title()
+	subtitle = item.Subtitle
+	switch item.Kind {
+	case types.ExploreSection:
+		icon = "▸"
+	case types.ExploreHeader:
+		icon = "▾"
+	case types.ExploreAlbum:
+		icon = "  ◉"
+	case types.ExplorePlaylist:
+		icon = "  ☰"
+	case types.ExploreArtist:
+		icon = "  ♪"
+	case types.ExploreMood:
+		icon = "  ◈"
+	case types.ExploreTrack:
+		icon = "  ♫"
+	}
+	return icon, title, subtitle
+}
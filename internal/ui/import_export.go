@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kumneger0/clispot/internal/playlistio"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// focusedTrackList returns the tracks of the list the user is looking at together with a name for it
+func (m *Model) focusedTrackList() (string, []*types.PlaylistTrackObject) {
+	var name string
+	var items []list.Item
+	switch {
+	case m.FocusedOn == QueueList && m.MusicQueueList != nil:
+		name = "Queue"
+		items = m.MusicQueueList.Items()
+	case m.FocusedOn == SearchResult:
+		name = "Search - " + m.SearchQuery
+		items = m.SearchResult.Items()
+	case m.MainViewMode.showsTrackList():
+		name = "Playlist"
+		if len(m.BreadcrumbItems) > 0 {
+			name = m.BreadcrumbItems[len(m.BreadcrumbItems)-1].Name
+		}
+		items = m.SelectedPlayListItems.Items()
+	}
+
+	var tracks []*types.PlaylistTrackObject
+	for _, item := range items {
+		switch item := item.(type) {
+		case types.PlaylistTrackObject:
+			tracks = append(tracks, &types.PlaylistTrackObject{Track: item.Track})
+		case types.Track:
+			tracks = append(tracks, &types.PlaylistTrackObject{Track: item})
+		}
+	}
+	return name, tracks
+}
+
+func defaultExportPath(name string) string {
+	fileName := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if fileName == "" {
+		fileName = "clispot"
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fileName + ".m3u8"
+	}
+	return filepath.Join(homeDir, fileName+".m3u8")
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err == nil {
+			return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
+
+func (m Model) openExportPrompt() (Model, tea.Cmd) {
+	name, tracks := m.focusedTrackList()
+	if len(tracks) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "there are no tracks to export here")
+	}
+	return m.openPrompt(ExportPrompt, "Export "+name+" to", name, defaultExportPath(name))
+}
+
+func (m Model) openImportPrompt() (Model, tea.Cmd) {
+	homeDir, _ := os.UserHomeDir()
+	value := ""
+	if homeDir != "" {
+		value = homeDir + string(filepath.Separator)
+	}
+	return m.openPrompt(ImportPrompt, "Import from", "", value)
+}
+
+func (m Model) exportTrackList(name, path string) (Model, tea.Cmd) {
+	path = expandHome(path)
+	format, err := playlistio.FormatFromPath(path)
+	if err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+	}
+	_, tracks := m.focusedTrackList()
+	cmd := func() tea.Msg {
+		file, err := os.Create(path)
+		if err != nil {
+			return types.PlaylistExportedMsg{Path: path, Err: err}
+		}
+		defer file.Close()
+		err = playlistio.Encode(file, format, name, tracks)
+		return types.PlaylistExportedMsg{
+			Path:  path,
+			Count: len(tracks),
+			Err:   err,
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) importTrackList(path string) (Model, tea.Cmd) {
+	path = expandHome(path)
+	format, err := playlistio.FormatFromPath(path)
+	if err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	cmd := func() tea.Msg {
+		file, err := os.Open(path)
+		if err != nil {
+			return types.PlaylistImportedMsg{Name: name, Err: err}
+		}
+		defer file.Close()
+		entries, err := playlistio.Decode(file, format)
+		if err != nil {
+			return types.PlaylistImportedMsg{Name: name, Err: err}
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		report, err := playlistio.Resolve(ctx, m.YtMusicClient, entries)
+		if err != nil {
+			return types.PlaylistImportedMsg{Name: name, Err: err}
+		}
+		var unmatched []string
+		for _, miss := range report.Unmatched {
+			unmatched = append(unmatched, miss.Name())
+		}
+		return types.PlaylistImportedMsg{
+			Name:      name,
+			Tracks:    report.Tracks,
+			Unmatched: unmatched,
+		}
+	}
+	return m, tea.Batch(SendLoadingCmd(), cmd)
+}
+
+func (m Model) handlePlaylistExportedMsg(msg types.PlaylistExportedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error(msg.Err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("export failed: %s", msg.Err.Error()))
+	}
+	return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("exported %d tracks to %s", msg.Count, msg.Path))
+}
+
+func (m Model) handlePlaylistImportedMsg(msg types.PlaylistImportedMsg) (Model, tea.Cmd) {
+	m.IsSearchLoading = false
+	if msg.Err != nil {
+		slog.Error(msg.Err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("import failed: %s", msg.Err.Error()))
+	}
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Imported", Icon: "⇪"}, {Name: msg.Name}}
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+	cmds := []tea.Cmd{
+		func() tea.Msg {
+			return types.UpdatePlaylistMsg{Playlist: msg.Tracks}
+		},
+		m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("imported %d tracks from %s", len(msg.Tracks), msg.Name)),
+	}
+	if len(msg.Unmatched) > 0 {
+		for _, name := range msg.Unmatched {
+			slog.Warn("no match found for imported track", "track", name)
+		}
+		report := strings.Join(msg.Unmatched[:min(len(msg.Unmatched), 3)], ", ")
+		if len(msg.Unmatched) > 3 {
+			report += fmt.Sprintf(" and %d more", len(msg.Unmatched)-3)
+		}
+		cmds = append(cmds, m.Alert.NewAlertCmd(bubbleup.WarnKey, fmt.Sprintf("%d unmatched: %s", len(msg.Unmatched), report)))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/palette"
+)
+
+// keyPanes are the panes whose bindings are active, the focused view first
+func (m *Model) keyPanes() []keymap.Pane {
+	switch m.FocusedOn {
+	case SideView:
+		return []keymap.Pane{keymap.Sidebar}
+	case QueueList:
+		return []keymap.Pane{keymap.Queue}
+	case SearchResult:
+		return []keymap.Pane{keymap.SearchResults}
+	case Player:
+		return []keymap.Pane{keymap.Player}
+	case MainView:
+		switch m.MainViewMode {
+		case AlbumMode:
+			return []keymap.Pane{keymap.Album}
+		case ArtistMode:
+			return []keymap.Pane{keymap.Artist}
+		case ExploreMode:
+			return []keymap.Pane{keymap.Explore}
+		case TrackInfoMode:
+			return []keymap.Pane{keymap.TrackInfo}
+		case BlocklistMode:
+			return []keymap.Pane{keymap.Blocklist}
+		case LyricsMode:
+			return []keymap.Pane{keymap.Lyrics}
+		}
+	}
+	return nil
+}
+
+// keyHint renders the key of an action followed by what it does, empty when the action is unbound
+func keyHint(m *Model, pane keymap.Pane, action keymap.Action, label string) string {
+	bound := m.Keys.Key(pane, action)
+	if bound == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render(bound) + dimmerStyle.Render(" "+label)
+}
+
+func joinHints(hints ...string) string {
+	var shown []string
+	for _, hint := range hints {
+		if hint != "" {
+			shown = append(shown, hint)
+		}
+	}
+	return strings.Join(shown, dimmerStyle.Render("  │  "))
+}
+
+func (m Model) openHelp() (Model, tea.Cmd) {
+	m.ShowHelp = true
+	dims := calculateLayoutDimensions(&m)
+	m.HelpView = viewport.New(dims.mainWidth, dims.contentHeight)
+	m.HelpView.SetContent(renderHelp(&m, dims.mainWidth))
+	return m, nil
+}
+
+// handleHelpKey scrolls the help, the help key or esc closes it
+func (m Model) handleHelpKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if action, ok := m.Keys.Lookup(msg.String()); msg.String() == "esc" || (ok && action == keymap.Help) {
+		m.ShowHelp = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.HelpView, cmd = m.HelpView.Update(msg)
+	return m, cmd
+}
+
+var paneTitles = map[keymap.Pane]string{
+	keymap.Global:        "Everywhere",
+	keymap.Player:        "Player",
+	keymap.Sidebar:       "Library",
+	keymap.Queue:         "Queue",
+	keymap.SearchResults: "Search results",
+	keymap.Album:         "Album",
+	keymap.Artist:        "Artist",
+	keymap.Explore:       "Explore",
+	keymap.TrackInfo:     "Track info",
+	keymap.Blocklist:     "Blocklist",
+	keymap.Lyrics:        "Lyrics",
+}
+
+// renderHelp lists the bindings of every pane, the panes active where the help was opened from first
+func renderHelp(m *Model, width int) string {
+	active := m.keyPanes()
+	panes := append(append([]keymap.Pane{}, active...), keymap.Global)
+	for _, pane := range keymap.Panes {
+		if !slices.Contains(panes, pane) {
+			panes = append(panes, pane)
+		}
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(accentColor).Bold(true).Width(14)
+	rows := []string{titleStyle.Render("Keys"), dimmerStyle.Render("rebind them in the \"keymap\" section of config.json"), ""}
+	section := func(title string, bindings []key.Binding, current bool) {
+		if len(bindings) == 0 {
+			return
+		}
+		if current {
+			title += "  (focused)"
+		}
+		rows = append(rows, dimStyle.Bold(true).Render(title))
+		for _, b := range bindings {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, keyStyle.Render(b.Help().Key), normalStyle.Render(b.Help().Desc)))
+		}
+		rows = append(rows, "")
+	}
+	for _, pane := range panes {
+		section(paneTitles[pane], m.Keys.Bindings(pane), slices.Contains(active, pane))
+	}
+	// the lists and the search input keep their own keys
+	section("Lists", []key.Binding{
+		key.NewBinding(key.WithKeys("up"), key.WithHelp("↑/k ↓/j", "move")),
+		key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup/pgdown", "page")),
+		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+	}, false)
+	section("Search", []key.Binding{
+		key.NewBinding(key.WithKeys("up"), key.WithHelp("↑/↓", "suggestions and history")),
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "search")),
+		key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close the suggestions")),
+	}, m.FocusedOn == SearchBar)
+	section("Mouse", []key.Binding{
+		key.NewBinding(key.WithKeys("click"), key.WithHelp("click", "focus the pane and select the row")),
+		key.NewBinding(key.WithKeys("double-click"), key.WithHelp("double-click", "play or open the row")),
+		key.NewBinding(key.WithKeys("wheel"), key.WithHelp("wheel", "scroll the pane under the cursor")),
+		key.NewBinding(key.WithKeys("progress"), key.WithHelp("progress bar", "click to seek")),
+	}, false)
+	// every action above is a command of the same name as well
+	var commands []key.Binding
+	for _, command := range palette.Commands() {
+		if command.Usage != "" {
+			commands = append(commands, key.NewBinding(key.WithKeys(command.Name),
+				key.WithHelp(":"+command.Name, command.Help+" "+dimmerStyle.Render(command.Usage))))
+		}
+	}
+	section("Commands", commands, false)
+	rows = append(rows, dimmerStyle.Render("esc close  │  ↑/↓ scroll"))
+	return lipgloss.NewStyle().Width(width).Padding(0, 0, 0, 1).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
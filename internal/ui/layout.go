@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/kumneger0/clispot/internal/layout"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+// the terminal keeps this many columns and rows free around the panes
+const windowMargin = 4
+
+// paneShown is whether the pane can take the focus, a folded sidebar or queue can not
+func paneShown(m *Model, pane FocusedOn) bool {
+	dims := calculateLayoutDimensions(m)
+	switch pane {
+	case SideView:
+		return dims.sidebar != layout.Hidden
+	case QueueList:
+		return dims.queue != layout.Hidden
+	}
+	return true
+}
+
+// overlayOpen is whether the sidebar or the queue is drawn in place of the main pane
+func overlayOpen(m *Model) bool {
+	dims := calculateLayoutDimensions(m)
+	return dims.sidebar == layout.Overlay || dims.queue == layout.Overlay
+}
+
+// togglePane shows or hides the sidebar or the queue, when the terminal is too narrow for it to be docked it opens
+// or closes its overlay
+func (m Model) togglePane(pane FocusedOn) Model {
+	if pane == SideView {
+		m.paneToggles.Sidebar = !m.paneToggles.Sidebar
+	} else {
+		m.paneToggles.Queue = !m.paneToggles.Queue
+	}
+	dims := calculateLayoutDimensions(&m)
+	mode := dims.sidebar
+	if pane == QueueList {
+		mode = dims.queue
+	}
+	switch {
+	case mode == layout.Overlay:
+		m.FocusedOn = pane
+	case mode == layout.Hidden && m.FocusedOn == pane:
+		m.FocusedOn = MainView
+	}
+	updateDelegate(&m)
+	return m
+}
+
+// closeBlurredOverlays closes the overlay the focus moved off, so only one is open at a time and esc, tab or a
+// click elsewhere brings the main pane back
+func (m Model) closeBlurredOverlays() Model {
+	dims := calculateLayoutDimensions(&m)
+	if dims.sidebar == layout.Overlay && m.FocusedOn != SideView {
+		m.paneToggles.Sidebar = false
+	}
+	if dims.queue == layout.Overlay && m.FocusedOn != QueueList {
+		m.paneToggles.Queue = false
+	}
+	return m
+}
+
+// resize keeps the panes the way the user left them until the terminal crosses a breakpoint, a pane folded away
+// while it had the focus gives it to the main pane
+func (m Model) resize(width, height int) Model {
+	before := calculateLayoutDimensions(&m)
+	m.Width = width - windowMargin
+	m.Height = height - windowMargin
+	after := calculateLayoutDimensions(&m)
+	if after.sidebar != before.sidebar {
+		m.paneToggles.Sidebar = false
+	}
+	if after.queue != before.queue {
+		m.paneToggles.Queue = false
+	}
+	if !paneShown(&m, m.FocusedOn) {
+		m.FocusedOn = MainView
+		updateDelegate(&m)
+	}
+	return m
+}
+
+// foldsLibrary is whether enter on the sidebar item folds the library tree instead of opening something
+func foldsLibrary(item list.Item) bool {
+	switch item := item.(type) {
+	case types.SidebarItem:
+		return isLibrarySidebarItem(item)
+	case types.LibraryGroupItem:
+		return true
+	}
+	return false
+}
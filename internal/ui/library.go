@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+const libraryLimit = 100
+
+type LibraryGroupState struct {
+	Name       string
+	IsExpanded bool
+	IsLoaded   bool
+	IsLoading  bool
+	Items      []types.LibraryItem
+}
+
+// Library is the tree shown under the "Library" sidebar entry, groups are only fetched the first time they are expanded
+type Library struct {
+	IsOpen bool
+	Groups map[types.LibraryGroup]*LibraryGroupState
+}
+
+var libraryGroupOrder = []types.LibraryGroup{types.LibraryPlaylists, types.LibraryAlbums, types.LibraryArtists}
+
+func NewLibrary() Library {
+	return Library{
+		Groups: map[types.LibraryGroup]*LibraryGroupState{
+			types.LibraryPlaylists: {Name: "Playlists"},
+			types.LibraryAlbums:    {Name: "Albums"},
+			types.LibraryArtists:   {Name: "Artists"},
+		},
+	}
+}
+
+func isLibrarySidebarItem(item types.SidebarItem) bool {
+	return strings.ToLower(strings.TrimSpace(item.Name)) == "library"
+}
+
+func (m Model) toggleLibrary() (Model, tea.Cmd) {
+	if m.Library.Groups == nil {
+		m.Library = NewLibrary()
+	}
+	m.Library.IsOpen = !m.Library.IsOpen
+	return m, m.rebuildSidebar()
+}
+
+func (m Model) setLibraryGroupExpanded(group types.LibraryGroup, expanded bool) (Model, tea.Cmd) {
+	state, ok := m.Library.Groups[group]
+	if !ok || state.IsExpanded == expanded {
+		return m, nil
+	}
+	state.IsExpanded = expanded
+	cmds := []tea.Cmd{}
+	if expanded && !state.IsLoaded && !state.IsLoading {
+		state.IsLoading = true
+		cmds = append(cmds, m.loadLibraryGroup(group))
+	}
+	cmds = append(cmds, m.rebuildSidebar())
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) loadLibraryGroup(group types.LibraryGroup) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var items []types.LibraryItem
+		switch group {
+		case types.LibraryPlaylists:
+			resp, err := m.YtMusicClient.GetUserPlaylists(ctx, &musicpb.GetUserPlaylistsRequest{Limit: libraryLimit})
+			if err != nil {
+				return types.LibraryGroupLoadedMsg{Group: group, Err: err}
+			}
+			for _, playlist := range resp.Playlists {
+				items = append(items, types.LibraryItem{
+					Group:    group,
+					ID:       playlist.PlaylistId,
+					Name:     playlist.Title,
+					Subtitle: fmt.Sprintf("%d tracks", playlist.Count),
+				})
+			}
+		case types.LibraryAlbums:
+			resp, err := m.YtMusicClient.GetUserSavedAlbums(ctx, &musicpb.GetUserSavedAlbumsRequest{Limit: libraryLimit})
+			if err != nil {
+				return types.LibraryGroupLoadedMsg{Group: group, Err: err}
+			}
+			for _, album := range resp.Albums {
+				var artistNames []string
+				for _, artist := range album.Artists {
+					artistNames = append(artistNames, artist.Name)
+				}
+				items = append(items, types.LibraryItem{
+					Group:    group,
+					ID:       album.BrowseId,
+					Name:     album.Title,
+					Subtitle: strings.Join(artistNames, ", "),
+				})
+			}
+		case types.LibraryArtists:
+			resp, err := m.YtMusicClient.GetFollowedArtists(ctx, &musicpb.GetFollowedArtistsRequest{Limit: libraryLimit})
+			if err != nil {
+				return types.LibraryGroupLoadedMsg{Group: group, Err: err}
+			}
+			for _, artist := range resp.Artists {
+				items = append(items, types.LibraryItem{
+					Group:    group,
+					ID:       artist.ChannelId,
+					Name:     artist.Name,
+					Subtitle: artist.Subscribers,
+				})
+			}
+		}
+		return types.LibraryGroupLoadedMsg{Group: group, Items: items}
+	}
+}
+
+func (m Model) handleLibraryGroupLoadedMsg(msg types.LibraryGroupLoadedMsg) (Model, tea.Cmd) {
+	state, ok := m.Library.Groups[msg.Group]
+	if !ok {
+		return m, nil
+	}
+	state.IsLoading = false
+	if msg.Err != nil {
+		slog.Error(msg.Err.Error())
+		// collapse so expanding the group again retries
+		state.IsExpanded = false
+		return m, tea.Batch(m.rebuildSidebar(), m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("failed to load %s: %s", strings.ToLower(state.Name), msg.Err.Error())))
+	}
+	state.IsLoaded = true
+	state.Items = msg.Items
+	return m, m.rebuildSidebar()
+}
+
+// invalidateLibraryGroup marks a group stale after a write, an expanded group is fetched again right away
+func (m *Model) invalidateLibraryGroup(group types.LibraryGroup) tea.Cmd {
+	state, ok := m.Library.Groups[group]
+	if !ok {
+		return nil
+	}
+	state.IsLoaded = false
+	if !state.IsExpanded || state.IsLoading {
+		return nil
+	}
+	state.IsLoading = true
+	return tea.Batch(m.loadLibraryGroup(group), m.rebuildSidebar())
+}
+
+// libraryTreeItems returns the rows shown below the "Library" entry
+func (m *Model) libraryTreeItems() []list.Item {
+	items := []list.Item{types.UserSavedTracksListItem{Name: "Liked songs"}}
+	for _, group := range libraryGroupOrder {
+		state := m.Library.Groups[group]
+		count := -1
+		if state.IsLoaded {
+			count = len(state.Items)
+		}
+		items = append(items, types.LibraryGroupItem{
+			Group:      group,
+			Name:       state.Name,
+			IsExpanded: state.IsExpanded,
+			IsLoading:  state.IsLoading,
+			Count:      count,
+		})
+		if !state.IsExpanded {
+			continue
+		}
+		switch {
+		case state.IsLoading:
+			items = append(items, types.LibraryItem{Group: group, Name: "loading…"})
+		case len(state.Items) == 0:
+			items = append(items, types.LibraryItem{Group: group, Name: "nothing here yet"})
+		default:
+			for _, item := range state.Items {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// rebuildSidebar lays the library tree out below the "Library" entry and keeps the cursor on the same row
+func (m *Model) rebuildSidebar() tea.Cmd {
+	selected := m.SideBarList.SelectedItem()
+	var items []list.Item
+	for _, item := range m.SideBarList.Items() {
+		sidebarItem, ok := item.(types.SidebarItem)
+		if !ok {
+			continue
+		}
+		items = append(items, sidebarItem)
+		if isLibrarySidebarItem(sidebarItem) && m.Library.IsOpen {
+			items = append(items, m.libraryTreeItems()...)
+		}
+	}
+	cmd := m.SideBarList.SetItems(items)
+	// when the selected row was folded away the cursor moves to the row it was folded into
+	for _, target := range []list.Item{selected, parentSidebarRow(selected)} {
+		for index, item := range items {
+			if sameSidebarRow(item, target) {
+				m.SideBarList.Select(index)
+				return cmd
+			}
+		}
+	}
+	return cmd
+}
+
+func parentSidebarRow(item list.Item) list.Item {
+	if libraryItem, ok := item.(types.LibraryItem); ok {
+		return types.LibraryGroupItem{Group: libraryItem.Group}
+	}
+	return types.SidebarItem{Name: "Library"}
+}
+
+func sameSidebarRow(a, b list.Item) bool {
+	switch b := b.(type) {
+	case types.LibraryGroupItem:
+		a, ok := a.(types.LibraryGroupItem)
+		return ok && a.Group == b.Group
+	case types.SidebarItem:
+		a, ok := a.(types.SidebarItem)
+		return ok && a.Name == b.Name
+	}
+	return a == b
+}
+
+func (m Model) openLibraryItem(item types.LibraryItem) (Model, tea.Cmd) {
+	if item.ID == "" {
+		return m, nil
+	}
+	switch item.Group {
+	case types.LibraryAlbums:
+		return m.openAlbum(item.ID, item.Name)
+	case types.LibraryArtists:
+		return m.openArtist(item.ID, item.Name)
+	}
+	group := m.Library.Groups[item.Group]
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Library", Icon: ""}, {Name: group.Name}, {Name: item.Name}}
+	m.PaginationInfo = nil
+	m.MainViewMode = NormalMode
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+
+	var cmd tea.Cmd
+	switch item.Group {
+	case types.LibraryPlaylists:
+		cmd = m.getPlaylistItems(item.ID)
+	}
+	return m, tea.Batch(SendLoadingCmd(), cmd)
+}
+
+func (m Model) openLikedSongs() (Model, tea.Cmd) {
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Library", Icon: ""}, {Name: "Liked songs", Icon: "♥"}}
+	m.PaginationInfo = nil
+	m.MainViewMode = NormalMode
+	m.FocusedOn = MainView
+	updateDelegate(&m)
+	return m, tea.Batch(SendLoadingCmd(), m.getUserSavedTracks())
+}
+
+// handleLibraryEnter handles enter on a sidebar row that belongs to the library, ok is false for every other row
+func (m Model) handleLibraryEnter() (Model, tea.Cmd, bool) {
+	switch item := m.SideBarList.SelectedItem().(type) {
+	case types.SidebarItem:
+		if !isLibrarySidebarItem(item) {
+			return m, nil, false
+		}
+		model, cmd := m.toggleLibrary()
+		return model, cmd, true
+	case types.LibraryGroupItem:
+		model, cmd := m.setLibraryGroupExpanded(item.Group, !item.IsExpanded)
+		return model, cmd, true
+	case types.LibraryItem:
+		model, cmd := m.openLibraryItem(item)
+		return model, cmd, true
+	case types.UserSavedTracksListItem:
+		model, cmd := m.openLikedSongs()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// collapseOrExpandLibrary handles left and right in the sidebar, left on a playlist, album or artist collapses its group
+func (m Model) collapseOrExpandLibrary(expand bool) (Model, tea.Cmd) {
+	switch item := m.SideBarList.SelectedItem().(type) {
+	case types.SidebarItem:
+		if isLibrarySidebarItem(item) && m.Library.IsOpen != expand {
+			return m.toggleLibrary()
+		}
+	case types.LibraryGroupItem:
+		return m.setLibraryGroupExpanded(item.Group, expand)
+	case types.LibraryItem:
+		if !expand {
+			return m.setLibraryGroupExpanded(item.Group, false)
+		}
+	}
+	return m, nil
+}
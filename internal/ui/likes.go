@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// TrackRating is how the user rated track, a rating made in this session wins over the liked state the track was loaded with
+func (m *Model) TrackRating(track types.Track) types.Rating {
+	if rating, ok := m.ratings[track.ID]; ok {
+		return rating
+	}
+	if track.Liked {
+		return types.RatingLike
+	}
+	return types.RatingNone
+}
+
+// IsTrackLiked reports whether track is liked
+func (m *Model) IsTrackLiked(track types.Track) bool {
+	return m.TrackRating(track) == types.RatingLike
+}
+
+// SetTrackRating records the rating of a track so every row showing it and the player agree
+func (m *Model) SetTrackRating(trackID string, rating types.Rating) {
+	if trackID == "" {
+		return
+	}
+	if m.ratings == nil {
+		m.ratings = map[string]types.Rating{}
+	}
+	m.ratings[trackID] = rating
+	if m.SelectedTrack != nil && m.SelectedTrack.Track != nil && m.SelectedTrack.Track.Track.ID == trackID {
+		m.SelectedTrack.rating = rating
+	}
+}
+
+// setTrackSaved records whether the track is in the liked songs, a track that is not keeps its dislike
+func (m *Model) setTrackSaved(trackID string, saved bool) {
+	switch {
+	case saved:
+		m.SetTrackRating(trackID, types.RatingLike)
+	case m.ratings[trackID] == types.RatingLike:
+		m.SetTrackRating(trackID, types.RatingNone)
+	}
+}
+
+// RateTrack likes, dislikes or clears the rating of a track on youtube music
+func (m *Model) RateTrack(ctx context.Context, trackID string, rating types.Rating) error {
+	var err error
+	switch rating {
+	case types.RatingLike:
+		_, err = m.YtMusicClient.LikeSong(ctx, &musicpb.LikeSongRequest{VideoId: trackID})
+	case types.RatingDislike:
+		_, err = m.YtMusicClient.DislikeSong(ctx, &musicpb.DislikeSongRequest{VideoId: trackID})
+	default:
+		_, err = m.YtMusicClient.UnlikeSong(ctx, &musicpb.UnlikeSongRequest{VideoId: trackID})
+	}
+	return err
+}
+
+// ToggleRating is rating, or no rating when track already has it
+func (m *Model) ToggleRating(track types.Track, rating types.Rating) types.Rating {
+	if m.TrackRating(track) == rating {
+		return types.RatingNone
+	}
+	return rating
+}
+
+// highlightedTrack returns the track under the cursor of the focused list
+func (m *Model) highlightedTrack() (types.Track, bool) {
+	switch {
+	case m.FocusedOn == MainView && m.MainViewMode.showsTrackList():
+		if track, ok := m.SelectedPlayListItems.SelectedItem().(types.PlaylistTrackObject); ok {
+			return track.Track, true
+		}
+	case m.FocusedOn == MainView && m.MainViewMode == ArtistMode:
+		if row, ok := m.Artist.List.SelectedItem().(types.ArtistPageItem); ok && row.Track != nil {
+			return *row.Track, true
+		}
+	case m.FocusedOn == MainView && m.MainViewMode == TrackInfoMode:
+		return m.TrackInfo.Track, true
+	case m.FocusedOn == MainView && m.MainViewMode == ExploreMode:
+		if row, ok := m.Explore.List.SelectedItem().(types.ExploreItem); ok && row.Track != nil {
+			return *row.Track, true
+		}
+	case m.FocusedOn == SearchResult && m.MainViewMode == SearchResultMode:
+		if track, ok := m.SearchResult.SelectedItem().(types.Track); ok {
+			return track, true
+		}
+	case m.FocusedOn == QueueList && m.MusicQueueList != nil:
+		if track, ok := m.MusicQueueList.SelectedItem().(types.PlaylistTrackObject); ok {
+			return track.Track, true
+		}
+	}
+	return types.Track{}, false
+}
+
+// rateTrack toggles the rating of track right away and rolls it back in handleRateTrackMsg if the request fails
+func (m Model) rateTrack(track types.Track, rating types.Rating) (Model, tea.Cmd) {
+	if track.ID == "" {
+		return m, nil
+	}
+	previous := m.TrackRating(track)
+	rating = m.ToggleRating(track, rating)
+	m.SetTrackRating(track.ID, rating)
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		err := m.RateTrack(ctx, track.ID, rating)
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.RateTrackMsg{
+			TrackID:   track.ID,
+			TrackName: track.Name,
+			Rating:    rating,
+			Previous:  previous,
+			Err:       err,
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) ratePlayingTrack(rating types.Rating) (Model, tea.Cmd) {
+	if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "nothing is playing")
+	}
+	return m.rateTrack(m.SelectedTrack.Track.Track, rating)
+}
+
+func (m Model) rateHighlightedTrack(rating types.Rating) (Model, tea.Cmd) {
+	track, ok := m.highlightedTrack()
+	if !ok {
+		return m, nil
+	}
+	return m.rateTrack(track, rating)
+}
+
+func (m Model) handleRateTrackMsg(msg types.RateTrackMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.SetTrackRating(msg.TrackID, msg.Previous)
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to rate the track: "+msg.Err.Error())
+	}
+	var message string
+	switch {
+	case msg.Rating == types.RatingLike:
+		message = "added " + msg.TrackName + " to liked songs"
+	case msg.Rating == types.RatingDislike:
+		message = "disliked " + msg.TrackName
+	case msg.Previous == types.RatingLike:
+		message = "removed " + msg.TrackName + " from liked songs"
+	default:
+		message = "removed the dislike of " + msg.TrackName
+	}
+	cmds := []tea.Cmd{m.Alert.NewAlertCmd(bubbleup.InfoKey, message)}
+	if m.isViewingLikedSongs() && (msg.Rating == types.RatingLike || msg.Previous == types.RatingLike) {
+		cmds = append(cmds, m.getUserSavedTracks())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) isViewingLikedSongs() bool {
+	if m.MainViewMode != NormalMode || len(m.BreadcrumbItems) == 0 {
+		return false
+	}
+	return m.BreadcrumbItems[len(m.BreadcrumbItems)-1].Name == "Liked songs"
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// focusedList is the list "/" filters, nil when the focused pane is not a list
+func (m *Model) focusedList() *list.Model {
+	switch m.FocusedOn {
+	case SideView:
+		return &m.SideBarList
+	case QueueList:
+		if m.MusicQueueList != nil {
+			return &m.MusicQueueList.Model
+		}
+	case SearchResult:
+		return &m.SearchResult
+	case MainView:
+		switch {
+		case m.MainViewMode.showsTrackList():
+			return &m.SelectedPlayListItems
+		case m.MainViewMode == HomePageMode:
+			return &m.HomePageList
+		case m.MainViewMode == ArtistMode:
+			return &m.Artist.List
+		case m.MainViewMode == ExploreMode:
+			return &m.Explore.List
+		case m.MainViewMode == BlocklistMode:
+			return &m.Blocklist
+		}
+	}
+	return nil
+}
+
+// isFilterKey reports whether the focused list takes the key for its filter instead of the key bindings,
+// while the filter is typed every key goes to it, and once applied esc clears it
+func (m *Model) isFilterKey(key string) bool {
+	l := m.focusedList()
+	if l == nil {
+		return false
+	}
+	return l.SettingFilter() || (l.IsFiltered() && key == "esc")
+}
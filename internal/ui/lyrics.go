@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/lyrics"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// LyricsPage is the lyrics of the playing track, ctrl+l or esc goes back to the view it was opened from
+type LyricsPage struct {
+	Track   types.Track
+	Lyrics  lyrics.Lyrics
+	loading bool
+	// the lyrics could not be loaded, the reason is shown instead of the lines
+	status string
+	// index of the line sung at the playback position, -1 before the first one
+	current             int
+	previousMode        MainViewMode
+	previousBreadcrumbs []types.Breadcrumb
+}
+
+func lyricsDir() string {
+	if dir := config.GetConfig().LyricsDir; dir != "" {
+		return dir
+	}
+	return lyrics.DefaultDir(runtime.GOOS)
+}
+
+func (m Model) openLyrics() (Model, tea.Cmd) {
+	if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "play a track to see its lyrics")
+	}
+	m.Lyrics.previousMode = m.MainViewMode
+	m.Lyrics.previousBreadcrumbs = m.BreadcrumbItems
+	m.MainViewMode = LyricsMode
+	m.FocusedOn = MainView
+	m.BreadcrumbItems = []types.Breadcrumb{{Name: "Lyrics", Icon: "♪"}}
+	// the zero viewport has no key bindings to scroll with
+	m.LyricsView.KeyMap = viewport.DefaultKeyMap()
+	updateDelegate(&m)
+	return m.getMusicLyrics(m.SelectedTrack)
+}
+
+func (m Model) closeLyrics() Model {
+	m.MainViewMode = m.Lyrics.previousMode
+	if m.MainViewMode == "" || m.MainViewMode == LyricsMode {
+		m.MainViewMode = NormalMode
+	}
+	m.BreadcrumbItems = m.Lyrics.previousBreadcrumbs
+	updateDelegate(&m)
+	return m
+}
+
+// getMusicLyrics loads the lyrics of the track, a local .lrc file takes precedence over youtube music
+func (m Model) getMusicLyrics(track *SelectedTrack) (Model, tea.Cmd) {
+	if track == nil || track.Track == nil {
+		return m, nil
+	}
+	song := track.Track.Track
+	m.Lyrics.Track = song
+	m.Lyrics.Lyrics = lyrics.Lyrics{}
+	m.Lyrics.loading = true
+	m.Lyrics.status = ""
+	m.Lyrics.current = -1
+	m.setLyricsContent()
+	m.LyricsView.GotoTop()
+	dir := lyricsDir()
+	cmd := func() tea.Msg {
+		artist := ""
+		if len(song.Artists) > 0 {
+			artist = song.Artists[0].Name
+		}
+		local, ok, err := lyrics.FindLocal(dir, song.ID, artist, song.Name)
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		if ok {
+			return types.LyricsLoadedMsg{TrackID: song.ID, Lyrics: local}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := m.YtMusicClient.GetLyrics(ctx, &musicpb.GetLyricsRequest{VideoId: song.ID})
+		if err != nil {
+			slog.Error(err.Error())
+			return types.LyricsLoadedMsg{TrackID: song.ID, Err: err}
+		}
+		if !resp.Found {
+			return types.LyricsLoadedMsg{TrackID: song.ID, NotFound: true}
+		}
+		return types.LyricsLoadedMsg{TrackID: song.ID, Lyrics: mapLyrics(resp)}
+	}
+	return m, cmd
+}
+
+func mapLyrics(resp *musicpb.GetLyricsResponse) lyrics.Lyrics {
+	if len(resp.Lines) == 0 {
+		result := lyrics.FromText(resp.Text)
+		result.Source = resp.Source
+		return result
+	}
+	result := lyrics.Lyrics{Synced: true, Source: resp.Source}
+	for _, line := range resp.Lines {
+		result.Lines = append(result.Lines, lyrics.Line{
+			Start: time.Duration(line.StartMs) * time.Millisecond,
+			Text:  line.Text,
+		})
+	}
+	return result
+}
+
+func (m Model) handleLyricsLoadedMsg(msg types.LyricsLoadedMsg) (Model, tea.Cmd) {
+	// playback moved on to another track while the lyrics were loading
+	if msg.TrackID != m.Lyrics.Track.ID {
+		return m, nil
+	}
+	m.Lyrics.loading = false
+	var cmd tea.Cmd
+	switch {
+	case msg.Err != nil:
+		m.Lyrics.status = "failed to load the lyrics"
+		cmd = m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to load the lyrics: "+msg.Err.Error())
+	case msg.NotFound || len(msg.Lyrics.Lines) == 0:
+		m.Lyrics.status = "no lyrics for this track, drop an .lrc file named after it in " + lyricsDir() + " to add them"
+	default:
+		m.Lyrics.Lyrics = msg.Lyrics
+	}
+	m.followLyrics()
+	m.setLyricsContent()
+	return m, cmd
+}
+
+// followLyrics moves the highlight to the line sung at the playback position and scrolls it to the middle
+func (m *Model) followLyrics() {
+	if m.Lyrics.Track.ID == "" || m.SelectedTrack == nil || m.SelectedTrack.Track == nil || m.SelectedTrack.Track.Track.ID != m.Lyrics.Track.ID {
+		return
+	}
+	current := m.Lyrics.Lyrics.LineAt(time.Duration(m.PlayedSeconds * float64(time.Second)))
+	if current == m.Lyrics.current {
+		return
+	}
+	m.Lyrics.current = current
+	offset := m.setLyricsContent()
+	if current >= 0 {
+		m.LyricsView.SetYOffset(offset - m.LyricsView.Height/2)
+	}
+}
+
+// setLyricsContent renders the lyrics into the viewport and returns the row the current line starts at
+func (m *Model) setLyricsContent() int {
+	width := max(m.LyricsView.Width-4, 10)
+	var artists []string
+	for _, artist := range m.Lyrics.Track.Artists {
+		artists = append(artists, artist.Name)
+	}
+	rows := []string{
+		titleStyle.Render(m.Lyrics.Track.Name),
+		dimStyle.Render(strings.Join(artists, ", ")),
+		"",
+	}
+	switch {
+	case m.Lyrics.loading:
+		rows = append(rows, dimmerStyle.Render("⟳ Loading lyrics..."))
+	case m.Lyrics.status != "":
+		rows = append(rows, dimStyle.Width(width).Render(m.Lyrics.status))
+	}
+	current := lipgloss.NewStyle().Foreground(accentColor).Bold(true).Width(width)
+	offset := 0
+	for i, line := range m.Lyrics.Lyrics.Lines {
+		text := line.Text
+		if text == "" && m.Lyrics.Lyrics.Synced {
+			text = "♪"
+		}
+		style := normalStyle.Width(width)
+		switch {
+		case i == m.Lyrics.current:
+			style = current
+			offset = lipgloss.Height(strings.Join(rows, "\n"))
+		case i < m.Lyrics.current:
+			style = dimStyle.Width(width)
+		}
+		rows = append(rows, style.Render(text))
+	}
+	if len(m.Lyrics.Lyrics.Lines) > 0 {
+		footer := m.Lyrics.Lyrics.Source
+		if !m.Lyrics.Lyrics.Synced {
+			footer = strings.TrimSpace(footer + "  (not synced)")
+		}
+		rows = append(rows, "", dimmerStyle.Render(footer))
+	}
+	m.LyricsView.SetContent(lipgloss.NewStyle().Padding(0, 0, 0, 2).Render(strings.Join(rows, "\n")))
+	return offset
+}
+
+// handleLyricsKey handles the actions of the lyrics view, ok is false for actions that are handled elsewhere
+func (m Model) handleLyricsKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	switch action {
+	case keymap.Back:
+		return m.closeLyrics(), nil, true
+	}
+	return m, nil, false
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/layout"
+)
+
+// two clicks on the same row within this time play it like enter does
+const doubleClickInterval = 400 * time.Millisecond
+
+// wheelLines is how far the lyrics and the help scroll for a turn of the wheel
+const wheelLines = 3
+
+type mouseClick struct {
+	pane  FocusedOn
+	index int
+	at    time.Time
+}
+
+// paneAt is the pane under the cell at x and y, and the position of the cell inside its border
+func paneAt(m *Model, x, y int) (pane FocusedOn, innerX, innerY int) {
+	dims := calculateLayoutDimensions(m)
+	// the panes are drawn with a border on every side
+	topHeight := dims.contentHeight + 2
+	if y >= topHeight {
+		return Player, x - 1, y - topHeight - 1
+	}
+	mainLeft := 0
+	if dims.sidebar == layout.Docked {
+		mainLeft = dims.sidebarWidth + 2
+		if x < mainLeft {
+			return SideView, x - 1, y - 1
+		}
+	}
+	mainRight := mainLeft + dims.mainWidth + 2
+	switch {
+	case x >= mainRight:
+		return QueueList, x - mainRight - 1, y - 1
+	case m.ShowHelp:
+		return MainView, x - mainLeft - 1, y - 1
+	case dims.sidebar == layout.Overlay:
+		return SideView, x - mainLeft - 1, y - 1
+	case dims.queue == layout.Overlay:
+		return QueueList, x - mainLeft - 1, y - 1
+	}
+	return MainView, x - mainLeft - 1, y - 1
+}
+
+// listHeaderHeight is the number of rows the title, the filter input and the status bar take above the items
+func listHeaderHeight(l *list.Model) int {
+	height := 0
+	if l.ShowTitle() || (l.ShowFilter() && l.FilteringEnabled()) {
+		height += lipgloss.Height(l.Styles.TitleBar.Render(l.Title))
+	}
+	if l.ShowStatusBar() {
+		height += lipgloss.Height(l.Styles.StatusBar.Render(""))
+	}
+	return height
+}
+
+// selectListRow selects the item drawn on the row of the list view, false when the row shows none
+func selectListRow(l *list.Model, row int) (int, bool) {
+	row -= listHeaderHeight(l)
+	itemHeight := CustomDelegate{}.Height() + CustomDelegate{}.Spacing()
+	if row < 0 || row/itemHeight >= l.Paginator.ItemsOnPage(len(l.VisibleItems())) {
+		return 0, false
+	}
+	index := l.Paginator.Page*l.Paginator.PerPage + row/itemHeight
+	l.Select(index)
+	return index, true
+}
+
+// searchResultListTop is the row the search results start on inside the main pane, as View stacks them
+func searchResultListTop(m *Model, width int) int {
+	searchWidth := width - (width * 10 / 100)
+	return lipgloss.Height(renderMainSearchBar(m, width)) + lipgloss.Height(searchResultHeader()) +
+		lipgloss.Height(renderSearchTabs(m, searchWidth)) + 1
+}
+
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	// the prompts and the playlist picker only take keys
+	if m.FocusedOn == PromptInput || m.FocusedOn == PlaylistPickerView || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	m.prepareLists()
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.scrollAt(msg.X, msg.Y, -1), nil
+	case tea.MouseButtonWheelDown:
+		return m.scrollAt(msg.X, msg.Y, 1), nil
+	case tea.MouseButtonLeft:
+		if m.ShowHelp {
+			return m, nil
+		}
+		return m.clickAt(msg.X, msg.Y)
+	}
+	return m, nil
+}
+
+// scrollAt moves the list or the text under the cursor without changing the focus
+func (m Model) scrollAt(x, y, direction int) Model {
+	scrollList := func(l *list.Model) {
+		if direction < 0 {
+			l.CursorUp()
+		} else {
+			l.CursorDown()
+		}
+	}
+	scrollViewport := func(scrollUp, scrollDown func(int) []string) {
+		if direction < 0 {
+			scrollUp(wheelLines)
+		} else {
+			scrollDown(wheelLines)
+		}
+	}
+	if m.ShowHelp {
+		scrollViewport(m.HelpView.ScrollUp, m.HelpView.ScrollDown)
+		return m
+	}
+	pane, _, _ := paneAt(&m, x, y)
+	switch pane {
+	case SideView:
+		scrollList(&m.SideBarList)
+	case QueueList:
+		if m.MusicQueueList != nil {
+			scrollList(&m.MusicQueueList.Model)
+		}
+	case MainView:
+		switch m.MainViewMode {
+		case LyricsMode:
+			scrollViewport(m.LyricsView.ScrollUp, m.LyricsView.ScrollDown)
+		case SearchResultMode:
+			scrollList(&m.SearchResult)
+		default:
+			if _, l := mainList(&m, calculateLayoutDimensions(&m).mainWidth); l != nil {
+				scrollList(l)
+			}
+		}
+	}
+	return m
+}
+
+// clickAt focuses the pane under the cursor and selects the row clicked, a second click on the row plays it
+func (m Model) clickAt(x, y int) (Model, tea.Cmd) {
+	pane, innerX, innerY := paneAt(&m, x, y)
+	if pane == Player {
+		return m.clickPlayer(innerX, innerY)
+	}
+	m.FocusedOn = pane
+	updateDelegate(&m)
+
+	var index int
+	var ok bool
+	switch pane {
+	case SideView:
+		index, ok = selectListRow(&m.SideBarList, innerY)
+	case QueueList:
+		if m.MusicQueueList != nil {
+			index, ok = selectListRow(&m.MusicQueueList.Model, innerY)
+		}
+	case MainView:
+		width := calculateLayoutDimensions(&m).mainWidth
+		if innerY < lipgloss.Height(renderMainSearchBar(&m, width)) {
+			m.FocusedOn = SearchBar
+			updateDelegate(&m)
+			return m, m.Search.Focus()
+		}
+		if m.MainViewMode == SearchResultMode {
+			top := searchResultListTop(&m, width)
+			if innerY >= top {
+				m.FocusedOn = SearchResult
+				updateDelegate(&m)
+				index, ok = selectListRow(&m.SearchResult, innerY-top)
+			}
+			break
+		}
+		above, l := mainList(&m, width)
+		if l == nil {
+			break
+		}
+		top := 1
+		for _, section := range above {
+			top += lipgloss.Height(section)
+		}
+		index, ok = selectListRow(l, innerY-top)
+	}
+	if !ok {
+		m.lastClick = mouseClick{}
+		return m, nil
+	}
+	now := time.Now()
+	if m.lastClick.pane == m.FocusedOn && m.lastClick.index == index && now.Sub(m.lastClick.at) < doubleClickInterval {
+		m.lastClick = mouseClick{}
+		return m.runAction(keymap.Select)
+	}
+	m.lastClick = mouseClick{pane: m.FocusedOn, index: index, at: now}
+	return m, nil
+}
+
+// clickPlayer seeks when the progress bar is clicked and runs the control clicked under it
+func (m Model) clickPlayer(x, y int) (Model, tea.Cmd) {
+	m.FocusedOn = Player
+	updateDelegate(&m)
+	playing := m.SelectedTrack != nil && m.SelectedTrack.Track != nil
+	if nowPlayingArtwork(&m) != "" {
+		x -= nowPlayingArtworkCols + 1
+	}
+	// View puts the track and the progress bar above the controls, an empty line when nothing plays
+	controlsRow := 1
+	if playing {
+		controlsRow = 2
+	}
+	switch {
+	case playing && y == 1:
+		width := progressBarWidth(&m)
+		if x < 0 || x >= width {
+			return m, nil
+		}
+		total := time.Duration(m.SelectedTrack.Track.Track.DurationMS) * time.Millisecond
+		return m.Seek(total * time.Duration(x) / time.Duration(width))
+	case y == controlsRow:
+		separatorWidth := lipgloss.Width(playerControlSeparator)
+		start := 0
+		for _, control := range playerControls {
+			end := start + lipgloss.Width(renderPlayerControl(&m, control))
+			if x >= start && x < end {
+				return m.runAction(control.action)
+			}
+			start = end + separatorWidth
+		}
+	}
+	return m, nil
+}
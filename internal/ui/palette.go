@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/palette"
+	"github.com/kumneger0/clispot/internal/theme"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// the command palette lists this many of the commands that match what was typed
+const maxCommandMatches = 6
+
+type sleepTimer struct {
+	// when the playback pauses, zero while no timer is set
+	at time.Time
+	// a tick of a timer that was replaced or turned off does nothing
+	seq int
+}
+
+// openCommandPalette opens the ":" command line, tab completes the highlighted match and up and down pick another
+func (m Model) openCommandPalette() (Model, tea.Cmd) {
+	m, cmd := m.openPrompt(CommandPrompt, "", "", "")
+	m.Prompt.Input.Prompt = ":"
+	m.Prompt.Input.ShowSuggestions = true
+	m.Prompt.Input.SetSuggestions(palette.Suggestions(map[string][]string{
+		"theme": theme.Names(theme.DefaultDir(runtime.GOOS)),
+	}))
+	return m, cmd
+}
+
+func (m Model) runCommand(line string) (Model, tea.Cmd) {
+	call, err := palette.Parse(line)
+	if err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+	}
+	if call.Args == "" && call.Command.Action != "" {
+		return m.dispatchAction(call.Command.Action)
+	}
+	switch call.Command.Name {
+	case "queue":
+		switch call.Args {
+		case "clear":
+			return m.clearQueue()
+		case "dedupe":
+			return m.removeQueueDuplicates()
+		}
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("unknown queue command %q, expected clear or dedupe", call.Args))
+	case "seek":
+		if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+			return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "nothing is playing")
+		}
+		position, err := palette.ParsePosition(call.Args, time.Duration(m.PlayedSeconds*float64(time.Second)))
+		if err != nil {
+			return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+		}
+		return m.Seek(position)
+	case "vol":
+		volume, err := palette.ParseVolume(call.Args, m.Volume)
+		if err != nil {
+			return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+		}
+		m = m.SetVolume(volume)
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("volume %d%%", volume))
+	case string(keymap.SaveQueue):
+		videoIDs := queueVideoIDs(&m)
+		if len(videoIDs) == 0 {
+			return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "the queue is empty")
+		}
+		return m, m.createPlaylist(call.Args, videoIDs)
+	case "theme":
+		return m.switchTheme(call.Args)
+	case "sleep":
+		return m.setSleepTimer(call.Args)
+	case string(keymap.Search):
+		return m.searchFromCommand(call.Args)
+	}
+	return m, nil
+}
+
+// SetVolume plays the track at the percent of the full volume, and the tracks after it
+func (m Model) SetVolume(percent int) Model {
+	m.Volume = percent
+	if m.PlayerProcess != nil && m.PlayerProcess.OtoPlayer != nil {
+		m.PlayerProcess.OtoPlayer.SetVolume(float64(percent) / 100)
+	}
+	return m
+}
+
+func (m Model) clearQueue() (Model, tea.Cmd) {
+	if m.MusicQueueList == nil || len(m.MusicQueueList.Items()) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "the queue is empty")
+	}
+	m.recordQueueChange("clear queue")
+	// the next page of the list the queue was filled from must not be appended to the empty queue
+	m.MusicQueueList.PaginationInfo = nil
+	return m, tea.Batch(m.MusicQueueList.SetItems(nil), m.Alert.NewAlertCmd(bubbleup.InfoKey, "cleared the queue"))
+}
+
+func (m Model) switchTheme(name string) (Model, tea.Cmd) {
+	t, err := theme.Load(name, theme.DefaultDir(runtime.GOOS), config.GetConfig().ThemeOverrides)
+	if err != nil {
+		slog.Error(err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+	}
+	ApplyTheme(t)
+	// the lyrics are coloured when they are laid out
+	if m.MainViewMode == LyricsMode {
+		m.setLyricsContent()
+	}
+	return m, nil
+}
+
+// setSleepTimer pauses the playback after the duration, without one it tells how long is left
+func (m Model) setSleepTimer(arg string) (Model, tea.Cmd) {
+	if arg == "" {
+		if m.sleep.at.IsZero() {
+			return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "no sleep timer is set")
+		}
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "pausing in "+time.Until(m.sleep.at).Round(time.Second).String())
+	}
+	d, err := palette.ParseSleep(arg)
+	if err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, err.Error())
+	}
+	m.sleep.seq++
+	if d == 0 {
+		m.sleep.at = time.Time{}
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "sleep timer off")
+	}
+	m.sleep.at = time.Now().Add(d)
+	seq := m.sleep.seq
+	return m, tea.Batch(
+		tea.Tick(d, func(time.Time) tea.Msg { return types.SleepTimerMsg{Seq: seq} }),
+		m.Alert.NewAlertCmd(bubbleup.InfoKey, "pausing in "+d.String()),
+	)
+}
+
+func (m Model) handleSleepTimerMsg(msg types.SleepTimerMsg) (Model, tea.Cmd) {
+	if msg.Seq != m.sleep.seq || m.sleep.at.IsZero() {
+		return m, nil
+	}
+	m.sleep.at = time.Time{}
+	if m.PlayerProcess == nil || m.PlayerProcess.OtoPlayer == nil || !m.PlayerProcess.OtoPlayer.IsPlaying() {
+		return m, nil
+	}
+	m, cmd := m.HandleMusicPausePlay()
+	return m, tea.Batch(cmd, m.Alert.NewAlertCmd(bubbleup.InfoKey, "sleep timer: paused the playback"))
+}
+
+// searchFromCommand searches the query of ":search", in the tab its prefix names
+func (m Model) searchFromCommand(arg string) (Model, tea.Cmd) {
+	tab, query := palette.ParseSearch(arg)
+	if query == "" {
+		return m.dispatchAction(keymap.Search)
+	}
+	m.SearchTabs.Tab = tab
+	m.Search.SetValue(query)
+	if err := m.SearchHistory.Add(query); err != nil {
+		slog.Error(err.Error())
+	}
+	return m.startSearch(query)
+}
+
+// renderCommandMatches lists the commands the typed line completes to with their help, the one tab accepts first
+func renderCommandMatches(m *Model) []string {
+	matches := m.Prompt.Input.MatchedSuggestions()
+	current := m.Prompt.Input.CurrentSuggestionIndex()
+	start := max(min(current-maxCommandMatches+1, len(matches)-maxCommandMatches), 0)
+	var rows []string
+	for i := start; i < min(start+maxCommandMatches, len(matches)); i++ {
+		name, _, _ := strings.Cut(matches[i], " ")
+		command, _ := palette.Lookup(name)
+		label := matches[i]
+		if strings.HasSuffix(label, " ") {
+			label += command.Usage
+		}
+		if i == current {
+			label = lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render("› " + label)
+		} else {
+			label = normalStyle.Render("  " + label)
+		}
+		rows = append(rows, label+dimmerStyle.Render("  "+command.Help))
+	}
+	return rows
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// PlaylistPicker lets the user pick one of their playlists, or create a new one, to save VideoIDs into
+type PlaylistPicker struct {
+	Title    string
+	List     list.Model
+	Input    textinput.Model
+	IsNaming bool
+	VideoIDs []string
+	// where to go back to once the picker is closed
+	previousMode  MainViewMode
+	previousFocus FocusedOn
+}
+
+func (m Model) openPlaylistPicker(title string, videoIDs []string) (Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "Playlist name"
+	input.Prompt = "> "
+	input.CharLimit = 150
+
+	previousMode, previousFocus := m.MainViewMode, m.FocusedOn
+	if m.MainViewMode == PlaylistPickerMode {
+		previousMode, previousFocus = m.PlaylistPicker.previousMode, m.PlaylistPicker.previousFocus
+	}
+	m.PlaylistPicker = PlaylistPicker{
+		Title:         title,
+		List:          list.New([]list.Item{}, CustomDelegate{Model: &m}, 10, 20),
+		Input:         input,
+		VideoIDs:      videoIDs,
+		previousMode:  previousMode,
+		previousFocus: previousFocus,
+	}
+	m.MainViewMode = PlaylistPickerMode
+	m.FocusedOn = PlaylistPickerView
+	m.Search.Blur()
+	updateDelegate(&m)
+
+	userPlaylistsCmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		playlists, err := m.YtMusicClient.GetUserPlaylists(ctx, &musicpb.GetUserPlaylistsRequest{})
+		return types.UserPlaylistsMsg{
+			Playlists: playlists,
+			Err:       err,
+		}
+	}
+	return m, userPlaylistsCmd
+}
+
+func (m Model) closePlaylistPicker() Model {
+	m.MainViewMode = m.PlaylistPicker.previousMode
+	m.FocusedOn = m.PlaylistPicker.previousFocus
+	if m.MainViewMode == "" || m.MainViewMode == PlaylistPickerMode {
+		m.MainViewMode = NormalMode
+	}
+	if m.FocusedOn == "" || m.FocusedOn == PlaylistPickerView {
+		m.FocusedOn = MainView
+	}
+	m.PlaylistPicker = PlaylistPicker{}
+	updateDelegate(&m)
+	return m
+}
+
+func queueVideoIDs(m *Model) []string {
+	var videoIDs []string
+	if m.MusicQueueList != nil {
+		for _, item := range m.MusicQueueList.Items() {
+			track, ok := item.(types.PlaylistTrackObject)
+			if !ok || track.Track.ID == "" {
+				continue
+			}
+			videoIDs = append(videoIDs, track.Track.ID)
+		}
+	}
+	return videoIDs
+}
+
+func (m Model) saveQueueAsPlaylist() (Model, tea.Cmd) {
+	videoIDs := queueVideoIDs(&m)
+	if len(videoIDs) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "the queue is empty")
+	}
+	return m.openPlaylistPicker("Save queue to playlist", videoIDs)
+}
+
+func (m Model) handleUserPlaylistsMsg(msg types.UserPlaylistsMsg) (Model, tea.Cmd) {
+	if m.MainViewMode != PlaylistPickerMode {
+		return m, nil
+	}
+	if msg.Err != nil {
+		slog.Error(msg.Err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	items := []list.Item{types.PlaylistPickerItem{Name: "New playlist"}}
+	if msg.Playlists != nil {
+		for _, playlist := range msg.Playlists.Playlists {
+			items = append(items, types.PlaylistPickerItem{
+				ID:    playlist.PlaylistId,
+				Name:  playlist.Title,
+				Count: int(playlist.Count),
+			})
+		}
+	}
+	cmd := m.PlaylistPicker.List.SetItems(items)
+	return m, cmd
+}
+
+func (m Model) handlePlaylistPickerKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if m.PlaylistPicker.IsNaming {
+			m.PlaylistPicker.IsNaming = false
+			m.PlaylistPicker.Input.Blur()
+			return m, nil
+		}
+		return m.closePlaylistPicker(), nil
+	case "ctrl+c":
+		return m.closePlaylistPicker(), nil
+	case "enter":
+		videoIDs := m.PlaylistPicker.VideoIDs
+		if m.PlaylistPicker.IsNaming {
+			name := strings.TrimSpace(m.PlaylistPicker.Input.Value())
+			if name == "" {
+				return m, nil
+			}
+			m = m.closePlaylistPicker()
+			return m, m.createPlaylist(name, videoIDs)
+		}
+		selectedItem, ok := m.PlaylistPicker.List.SelectedItem().(types.PlaylistPickerItem)
+		if !ok {
+			return m, nil
+		}
+		if selectedItem.ID == "" {
+			m.PlaylistPicker.IsNaming = true
+			return m, m.PlaylistPicker.Input.Focus()
+		}
+		m = m.closePlaylistPicker()
+		return m, m.addTracksToPlaylist(selectedItem.ID, selectedItem.Name, videoIDs)
+	}
+	return m, nil
+}
+
+func (m Model) createPlaylist(name string, videoIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		resp, err := m.YtMusicClient.CreatePlaylist(ctx, &musicpb.CreatePlaylistRequest{
+			Title:         name,
+			PrivacyStatus: "PRIVATE",
+			VideoIds:      videoIDs,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return types.PlaylistSavedMsg{Name: name, Err: err}
+		}
+		return types.PlaylistSavedMsg{
+			PlaylistID: resp.PlaylistId,
+			Name:       name,
+			TrackCount: len(videoIDs),
+		}
+	}
+}
+
+func (m Model) addTracksToPlaylist(playlistID, name string, videoIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.AddPlaylistItems(ctx, &musicpb.AddPlaylistItemsRequest{
+			PlaylistId: playlistID,
+			VideoIds:   videoIDs,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.PlaylistSavedMsg{
+			PlaylistID: playlistID,
+			Name:       name,
+			TrackCount: len(videoIDs),
+			Err:        err,
+		}
+	}
+}
+
+func (m Model) handlePlaylistSavedMsg(msg types.PlaylistSavedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("failed to save to %s: %s", msg.Name, msg.Err.Error()))
+	}
+	cmds := []tea.Cmd{m.invalidateLibraryGroup(types.LibraryPlaylists)}
+	if m.isViewingPlaylist() && m.Playlist.ID == msg.PlaylistID {
+		cmds = append(cmds, m.getPlaylistItems(msg.PlaylistID))
+	}
+	message := fmt.Sprintf("saved %d tracks to %s", msg.TrackCount, msg.Name)
+	switch msg.TrackCount {
+	case 0:
+		message = "created " + msg.Name
+	case 1:
+		message = "saved 1 track to " + msg.Name
+	}
+	cmds = append(cmds, m.Alert.NewAlertCmd(bubbleup.InfoKey, message))
+	return m, tea.Batch(cmds...)
+}
+
+func renderPlaylistPicker(m *Model) string {
+	header := titleStyle.Render("  " + m.PlaylistPicker.Title)
+	if m.PlaylistPicker.IsNaming {
+		hint := dimmerStyle.Render("  enter to create • esc to go back")
+		input := lipgloss.NewStyle().Padding(1, 0, 0, 2).Render(m.PlaylistPicker.Input.View())
+		return lipgloss.JoinVertical(lipgloss.Top, header, input, hint)
+	}
+	hint := dimmerStyle.Render("  enter to save • esc to cancel")
+	if len(m.PlaylistPicker.List.Items()) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Top, header, dimmerStyle.Render("  ⟳ Loading playlists..."))
+	}
+	removeListDefaults(&m.PlaylistPicker.List)
+	m.PlaylistPicker.List.SetShowTitle(false)
+	return lipgloss.JoinVertical(lipgloss.Top, header, hint, lipgloss.NewStyle().Padding(1, 0, 0, 0).Render(m.PlaylistPicker.List.View()))
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/listfilter"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// PlaylistPage is the playlist whose tracks are in SelectedPlayListItems
+type PlaylistPage struct {
+	ID    string
+	Title string
+	// only the user's own playlists can be edited, the ones saved from others are read only
+	Owned bool
+}
+
+func (m *Model) isViewingPlaylist() bool {
+	return m.MainViewMode == NormalMode && m.Playlist.ID != ""
+}
+
+func (m *Model) isViewingOwnPlaylist() bool {
+	return m.isViewingPlaylist() && m.Playlist.Owned
+}
+
+func (m Model) addHighlightedTrackToPlaylist() (Model, tea.Cmd) {
+	track, ok := m.highlightedTrack()
+	if !ok || track.ID == "" {
+		return m, nil
+	}
+	return m.openPlaylistPicker("Add "+track.Name+" to playlist", []string{track.ID})
+}
+
+func (m Model) removeHighlightedTrackFromPlaylist() (Model, tea.Cmd) {
+	if m.FocusedOn != MainView || !m.isViewingOwnPlaylist() {
+		return m, nil
+	}
+	track, ok := m.SelectedPlayListItems.SelectedItem().(types.PlaylistTrackObject)
+	if !ok {
+		return m, nil
+	}
+	playlistID := m.Playlist.ID
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.RemovePlaylistItems(ctx, &musicpb.RemovePlaylistItemsRequest{
+			PlaylistId: playlistID,
+			Items: []*musicpb.PlaylistItem{{
+				VideoId:    track.Track.ID,
+				SetVideoId: track.Track.SetVideoID,
+			}},
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.PlaylistItemsRemovedMsg{
+			PlaylistID: playlistID,
+			Track:      track.Track,
+			Err:        err,
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) handlePlaylistItemsRemovedMsg(msg types.PlaylistItemsRemovedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("failed to remove %s: %s", msg.Track.Name, msg.Err.Error()))
+	}
+	cmds := []tea.Cmd{m.invalidateLibraryGroup(types.LibraryPlaylists)}
+	name := "the playlist"
+	if m.isViewingPlaylist() && m.Playlist.ID == msg.PlaylistID {
+		name = m.Playlist.Title
+		for index, item := range m.SelectedPlayListItems.Items() {
+			track, ok := item.(types.PlaylistTrackObject)
+			if !ok || track.Track.ID != msg.Track.ID || track.Track.SetVideoID != msg.Track.SetVideoID {
+				continue
+			}
+			cmds = append(cmds, listfilter.RemoveGlobal(&m.SelectedPlayListItems, index))
+			break
+		}
+	}
+	cmds = append(cmds, m.Alert.NewAlertCmd(bubbleup.InfoKey, fmt.Sprintf("removed %s from %s", msg.Track.Name, name)))
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) openCreatePlaylistPrompt() (Model, tea.Cmd) {
+	return m.openPrompt(CreatePlaylistPrompt, "New playlist", "", "")
+}
+
+func (m Model) openRenamePlaylistPrompt() (Model, tea.Cmd) {
+	if !m.isViewingOwnPlaylist() {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "open one of your playlists to rename it")
+	}
+	return m.openPrompt(RenamePlaylistPrompt, "Rename playlist", m.Playlist.ID, m.Playlist.Title)
+}
+
+func (m Model) openDeletePlaylistPrompt() (Model, tea.Cmd) {
+	if !m.isViewingOwnPlaylist() {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "open one of your playlists to delete it")
+	}
+	return m.openPrompt(DeletePlaylistPrompt, fmt.Sprintf("Delete %s? type yes", m.Playlist.Title), m.Playlist.ID, "")
+}
+
+func (m Model) renamePlaylist(playlistID, title string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.EditPlaylist(ctx, &musicpb.EditPlaylistRequest{
+			PlaylistId: playlistID,
+			Title:      title,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.PlaylistEditedMsg{
+			PlaylistID: playlistID,
+			Title:      title,
+			Err:        err,
+		}
+	}
+}
+
+func (m Model) handlePlaylistEditedMsg(msg types.PlaylistEditedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to rename the playlist: "+msg.Err.Error())
+	}
+	if m.Playlist.ID == msg.PlaylistID {
+		m.Playlist.Title = msg.Title
+		if len(m.BreadcrumbItems) > 0 {
+			m.BreadcrumbItems[len(m.BreadcrumbItems)-1].Name = msg.Title
+		}
+	}
+	return m, tea.Batch(
+		m.invalidateLibraryGroup(types.LibraryPlaylists),
+		m.Alert.NewAlertCmd(bubbleup.InfoKey, "renamed the playlist to "+msg.Title),
+	)
+}
+
+func (m Model) deletePlaylist(playlistID, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := m.YtMusicClient.DeletePlaylist(ctx, &musicpb.DeletePlaylistRequest{
+			PlaylistId: playlistID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		return types.PlaylistDeletedMsg{
+			PlaylistID: playlistID,
+			Name:       name,
+			Err:        err,
+		}
+	}
+}
+
+func (m Model) handlePlaylistDeletedMsg(msg types.PlaylistDeletedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, fmt.Sprintf("failed to delete %s: %s", msg.Name, msg.Err.Error()))
+	}
+	var cmds []tea.Cmd
+	if m.isViewingPlaylist() && m.Playlist.ID == msg.PlaylistID {
+		m.Playlist = PlaylistPage{}
+		m.BreadcrumbItems = nil
+		m.PaginationInfo = nil
+		cmds = append(cmds, m.SelectedPlayListItems.SetItems(nil))
+	}
+	cmds = append(cmds,
+		m.invalidateLibraryGroup(types.LibraryPlaylists),
+		m.Alert.NewAlertCmd(bubbleup.InfoKey, "deleted "+msg.Name),
+	)
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) handlePlaylistPromptValue(prompt Prompt, value string) (Model, tea.Cmd) {
+	switch prompt.Kind {
+	case CreatePlaylistPrompt:
+		return m, m.createPlaylist(value, nil)
+	case RenamePlaylistPrompt:
+		return m, m.renamePlaylist(prompt.Target, value)
+	case DeletePlaylistPrompt:
+		if !strings.EqualFold(value, "yes") {
+			return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "the playlist was not deleted")
+		}
+		return m, m.deletePlaylist(prompt.Target, m.Playlist.Title)
+	}
+	return m, nil
+}
+
+func (m Model) handlePlaylistKey(action keymap.Action) (Model, tea.Cmd) {
+	switch action {
+	case keymap.AddToPlaylist:
+		return m.addHighlightedTrackToPlaylist()
+	case keymap.RemoveFromPlaylist:
+		return m.removeHighlightedTrackFromPlaylist()
+	case keymap.NewPlaylist:
+		return m.openCreatePlaylistPrompt()
+	case keymap.RenamePlaylist:
+		return m.openRenamePlaylistPrompt()
+	case keymap.DeletePlaylist:
+		return m.openDeletePlaylistPrompt()
+	}
+	return m, nil
+}
+
+func renderPlaylistHints(m *Model) string {
+	key := lipgloss.NewStyle().Foreground(accentColor).Bold(true)
+	hints := []string{keyHint(m, keymap.Global, keymap.AddToPlaylist, "add to playlist")}
+	if m.Playlist.Owned {
+		hints = append(hints,
+			keyHint(m, keymap.Global, keymap.RemoveFromPlaylist, "remove"),
+			keyHint(m, keymap.Global, keymap.RenamePlaylist, "rename"),
+			keyHint(m, keymap.Global, keymap.DeletePlaylist, "delete"),
+		)
+	}
+	hints = append(hints, key.Render("/")+dimmerStyle.Render(" filter"), keyHint(m, keymap.Global, keymap.Help, "keys"))
+	return lipgloss.NewStyle().Padding(0, 0, 0, 1).Render(joinHints(hints...))
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PromptKind string
+
+const (
+	ExportPrompt         PromptKind = "EXPORT"
+	ImportPrompt         PromptKind = "IMPORT"
+	CreatePlaylistPrompt PromptKind = "CREATE_PLAYLIST"
+	RenamePlaylistPrompt PromptKind = "RENAME_PLAYLIST"
+	DeletePlaylistPrompt PromptKind = "DELETE_PLAYLIST"
+	ChartsCountryPrompt  PromptKind = "CHARTS_COUNTRY"
+	CommandPrompt        PromptKind = "COMMAND"
+)
+
+// Prompt is a one line input shown in place of the search bar, used for actions that need a bit of text like a file path
+type Prompt struct {
+	Kind  PromptKind
+	Label string
+	Input textinput.Model
+	// what the prompt acts on, e.g. the name of the list to export
+	Target        string
+	previousFocus FocusedOn
+}
+
+func (m Model) openPrompt(kind PromptKind, label, target, value string) (Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = label + " › "
+	input.CharLimit = 4096
+	input.SetValue(value)
+	input.CursorEnd()
+
+	previousFocus := m.FocusedOn
+	if previousFocus == PromptInput {
+		previousFocus = m.Prompt.previousFocus
+	}
+	m.Prompt = Prompt{
+		Kind:          kind,
+		Label:         label,
+		Input:         input,
+		Target:        target,
+		previousFocus: previousFocus,
+	}
+	m.FocusedOn = PromptInput
+	m.Search.Blur()
+	updateDelegate(&m)
+	return m, m.Prompt.Input.Focus()
+}
+
+func (m Model) closePrompt() Model {
+	m.FocusedOn = m.Prompt.previousFocus
+	if m.FocusedOn == "" || m.FocusedOn == PromptInput {
+		m.FocusedOn = MainView
+	}
+	m.Prompt = Prompt{}
+	updateDelegate(&m)
+	return m
+}
+
+func (m Model) handlePromptKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		return m.closePrompt(), nil
+	case "enter":
+		prompt := m.Prompt
+		value := strings.TrimSpace(prompt.Input.Value())
+		if value == "" {
+			return m, nil
+		}
+		m = m.closePrompt()
+		switch prompt.Kind {
+		case ExportPrompt:
+			return m.exportTrackList(prompt.Target, value)
+		case ImportPrompt:
+			return m.importTrackList(value)
+		case CreatePlaylistPrompt, RenamePlaylistPrompt, DeletePlaylistPrompt:
+			return m.handlePlaylistPromptValue(prompt, value)
+		case ChartsCountryPrompt:
+			return m.setChartsCountry(value)
+		case CommandPrompt:
+			return m.runCommand(value)
+		}
+	}
+	return m, nil
+}
+
+func renderPrompt(m *Model, width int) string {
+	if width < 20 {
+		width = 20
+	}
+	m.Prompt.Input.Width = width - len(m.Prompt.Input.Prompt) - 4
+	box := lipgloss.NewStyle().
+		Width(width).
+		Padding(0, 1).
+		BorderBottom(true).
+		BorderStyle(separatorBorder).
+		BorderForeground(borderFocused).
+		Foreground(textPrimary)
+	rows := []string{strings.TrimRight(m.Prompt.Input.View(), "\n")}
+	if m.Prompt.Kind == CommandPrompt {
+		rows = append(rows, renderCommandMatches(m)...)
+	}
+	return strings.TrimRight(box.Render(lipgloss.JoinVertical(lipgloss.Left, rows...)), "\n")
+}
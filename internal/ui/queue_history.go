@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kumneger0/clispot/internal/listfilter"
+	"go.dalton.dog/bubbleup"
+)
+
+// recordQueueChange remembers the queue as it is right now so the change that is about to happen can be undone
+func (m *Model) recordQueueChange(label string) {
+	if m.MusicQueueList == nil {
+		return
+	}
+	m.QueueHistory.Record(label, slices.Clone(m.MusicQueueList.Items()))
+}
+
+func (m Model) undoQueueChange() (Model, tea.Cmd) {
+	if m.MusicQueueList == nil {
+		return m, nil
+	}
+	snapshot, ok := m.QueueHistory.Undo(slices.Clone(m.MusicQueueList.Items()))
+	if !ok {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "nothing to undo")
+	}
+	return m.restoreQueue(snapshot.State, "undone: "+snapshot.Label)
+}
+
+func (m Model) redoQueueChange() (Model, tea.Cmd) {
+	if m.MusicQueueList == nil {
+		return m, nil
+	}
+	snapshot, ok := m.QueueHistory.Redo(slices.Clone(m.MusicQueueList.Items()))
+	if !ok {
+		return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "nothing to redo")
+	}
+	return m.restoreQueue(snapshot.State, "redone: "+snapshot.Label)
+}
+
+func (m Model) restoreQueue(items []list.Item, message string) (Model, tea.Cmd) {
+	index := m.MusicQueueList.GlobalIndex()
+	cmd := m.MusicQueueList.SetItems(items)
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	listfilter.SelectGlobal(&m.MusicQueueList.Model, max(index, 0))
+	return m, tea.Batch(cmd, m.Alert.NewAlertCmd(bubbleup.InfoKey, message))
+}
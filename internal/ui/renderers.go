@@ -10,6 +10,9 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/filter"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/listfilter"
 	"github.com/kumneger0/clispot/internal/types"
 )
 
@@ -30,6 +33,9 @@ func (d CustomDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	return nil
 }
 
+// shown after the title of liked tracks
+const likedMark = " ♥"
+
 func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
 	var title string
 	var isSelected bool
@@ -43,6 +49,12 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		switch item.Kind() {
 		case types.SearchResultTrack:
 			icon = "♫"
+			if t, ok := item.(types.Track); ok && d.Model != nil && d.Model.IsTrackLiked(t) {
+				title += likedMark
+			}
+			if t, ok := item.(types.Track); ok && d.Model != nil && t.Explicit && d.Model.Filter.Explicit() == filter.ExplicitSkip {
+				title += explicitMark
+			}
 			if t, ok := item.(types.Track); ok && len(t.Artists) > 0 {
 				var names []string
 				for _, a := range t.Artists {
@@ -75,6 +87,12 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 	case types.PlaylistTrackObject:
 		icon = "♫"
 		title = item.Track.Name
+		if d.Model != nil && d.Model.IsTrackLiked(item.Track) {
+			title += likedMark
+		}
+		if d.Model != nil && item.Track.Explicit && d.Model.Filter.Explicit() == filter.ExplicitSkip {
+			title += explicitMark
+		}
 		if len(item.Track.Artists) > 0 {
 			var names []string
 			for _, a := range item.Track.Artists {
@@ -82,6 +100,11 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 			}
 			subtitle = strings.Join(names, ", ")
 		}
+		if d.Model != nil && d.Model.MainViewMode == AlbumMode && !item.IsItFromQueue && !item.IsItFromSearch {
+			// album tracks are numbered and all share the album artist, so show the length instead
+			icon = fmt.Sprintf("%2d", index+1)
+			subtitle = formatTime(time.Duration(item.Track.DurationMS) * time.Millisecond)
+		}
 		if d.Model != nil {
 			switch d.Model.FocusedOn {
 			case QueueList:
@@ -113,34 +136,102 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		if d.Model != nil && d.Model.FocusedOn == MainView && d.Model.MainViewMode == HomePageMode {
 			isSelected = m.Index() == index
 		}
+	case types.PlaylistPickerItem:
+		title = item.Name
+		if item.ID == "" {
+			icon = "+"
+		} else {
+			icon = "☰"
+			subtitle = fmt.Sprintf("%d tracks", item.Count)
+		}
+		if d.Model != nil && d.Model.FocusedOn == PlaylistPickerView {
+			isSelected = m.Index() == index
+		}
 	case types.UserSavedTracksListItem:
 		title = item.FilterValue()
 		if d.Model != nil {
-			icon = "♥"
+			// indented, it is listed under the "Library" entry
+			icon = "  ♥"
 			isSelected = d.Model.FocusedOn == SideView && m.Index() == index
 		}
+	case types.ArtistPageItem:
+		icon, title, subtitle = renderArtistPageItem(item)
+		if item.Track != nil && d.Model != nil && d.Model.IsTrackLiked(*item.Track) {
+			title += likedMark
+		}
+		if d.Model != nil && d.Model.FocusedOn == MainView && d.Model.MainViewMode == ArtistMode {
+			isSelected = m.Index() == index
+		}
+	case types.ExploreItem:
+		icon, title, subtitle = renderExploreItem(item)
+		if item.Track != nil && d.Model != nil && d.Model.IsTrackLiked(*item.Track) {
+			title += likedMark
+		}
+		if d.Model != nil && d.Model.FocusedOn == MainView && d.Model.MainViewMode == ExploreMode {
+			isSelected = m.Index() == index
+		}
+	case types.BlocklistItem:
+		title = item.Name
+		icon = "♫"
+		subtitle = "track"
+		if item.IsArtist {
+			icon = "♪"
+			subtitle = "artist"
+		}
+		if d.Model != nil && d.Model.FocusedOn == MainView && d.Model.MainViewMode == BlocklistMode {
+			isSelected = m.Index() == index
+		}
+	case types.LibraryGroupItem:
+		icon = "  ▸"
+		if item.IsExpanded {
+			icon = "  ▾"
+		}
+		title = item.Name
+		if item.Count >= 0 {
+			subtitle = fmt.Sprintf("%d", item.Count)
+		}
+		if d.Model != nil && d.Model.FocusedOn == SideView {
+			isSelected = m.Index() == index
+		}
+	case types.LibraryItem:
+		title = item.Name
+		switch {
+		case item.ID == "":
+			icon = "    "
+		case item.Group == types.LibraryAlbums:
+			icon = "    ◉"
+		case item.Group == types.LibraryArtists:
+			icon = "    ♪"
+		default:
+			icon = "    ☰"
+		}
+		subtitle = item.Subtitle
+		if d.Model != nil && d.Model.FocusedOn == SideView {
+			isSelected = m.Index() == index
+		}
 	}
 
 	availableWidth := m.Width()
 	if availableWidth <= 0 {
 		availableWidth = 40
 	}
+	titleMatches, subtitleMatches := listfilter.SplitMatches(m.MatchesForItem(index), item.FilterValue(), title, subtitle)
 	var rendered string
 	if subtitle != "" && availableWidth > len(title)+5 {
 		if isSelected {
-			rendered = selectedStyle.Render(fmt.Sprintf(" %s %s", icon, title)) +
-				selectedStyle.Foreground(lipgloss.Color("#D4D4D8")).Render(fmt.Sprintf(" · %s ", subtitle))
+			subtitleStyle := selectedStyle.Foreground(selectedSubtitle)
+			rendered = selectedStyle.Render(fmt.Sprintf(" %s ", icon)) + listfilter.HighlightMatches(title, titleMatches, selectedStyle) +
+				subtitleStyle.Render(" · ") + listfilter.HighlightMatches(subtitle, subtitleMatches, subtitleStyle) + subtitleStyle.Render(" ")
 		} else {
-			rendered = normalStyle.Render(fmt.Sprintf(" %s %s", icon, title)) +
-				dimStyle.Render(fmt.Sprintf(" · %s ", subtitle))
+			rendered = normalStyle.Render(fmt.Sprintf(" %s ", icon)) + listfilter.HighlightMatches(title, titleMatches, normalStyle) +
+				dimStyle.Render(" · ") + listfilter.HighlightMatches(subtitle, subtitleMatches, dimStyle) + dimStyle.Render(" ")
 		}
 	} else {
-		str := fmt.Sprintf(" %s %s ", icon, title)
+		style := normalStyle
 		if isSelected {
-			rendered = selectedStyle.Render(str)
-		} else {
-			rendered = normalStyle.Render(str)
+			style = selectedStyle
 		}
+		rendered = style.Render(fmt.Sprintf(" %s ", icon)) + listfilter.HighlightMatches(title, titleMatches, style) + style.Render(" ")
 	}
 
 	fmt.Fprint(w, rendered)
@@ -157,7 +248,7 @@ func renderSearchBar(m *Model, width int) string {
 		Padding(0, 1).
 		Margin(0).
 		BorderBottom(true).
-		BorderStyle(lipgloss.NormalBorder()).
+		BorderStyle(separatorBorder).
 		BorderForeground(borderNormal).
 		Foreground(textPrimary)
 
@@ -167,7 +258,19 @@ func renderSearchBar(m *Model, width int) string {
 	} else {
 		content = strings.TrimRight(m.Search.View(), "\n")
 	}
-	return strings.TrimRight(box.Render(content), "\n")
+	bar := strings.TrimRight(box.Render(content), "\n")
+	if m.FocusedOn == SearchBar && m.Suggestions.isOpen() {
+		return lipgloss.JoinVertical(lipgloss.Left, bar, renderSuggestions(m, width))
+	}
+	return bar
+}
+
+// progressBarWidth is the width of the progress bar, the cover art takes some of the player when it is shown
+func progressBarWidth(m *Model) int {
+	if nowPlayingArtwork(m) != "" {
+		return m.Width - nowPlayingArtworkCols - 1
+	}
+	return m.Width
 }
 
 func renderNowPlaying(m *Model, currentPosition, TotalDuration time.Duration) string {
@@ -184,13 +287,14 @@ func renderNowPlaying(m *Model, currentPosition, TotalDuration time.Duration) st
 	trackName := selectedTrack.Track.Track.Name
 
 	var likedIndicator string
-	if selectedTrack.isLiked {
+	switch selectedTrack.rating {
+	case types.RatingLike:
 		likedIndicator = " ♥"
-	} else {
-		likedIndicator = ""
+	case types.RatingDislike:
+		likedIndicator = " ♡ disliked"
 	}
 
-	barWidth := m.Width
+	barWidth := progressBarWidth(m)
 	var progressFloat float64
 	if TotalDuration == 0 {
 		progressFloat = 1.0
@@ -207,7 +311,10 @@ func renderNowPlaying(m *Model, currentPosition, TotalDuration time.Duration) st
 	)
 	artistInfo := dimStyle.Render(fmt.Sprintf(" — %s", artistName))
 	timeInfo := dimStyle.Render(fmt.Sprintf("  %s / %s", formatTime(currentPosition), formatTime(TotalDuration)))
-	likeInfo := lipgloss.NewStyle().Foreground(lipgloss.Color("#F87171")).Render(likedIndicator)
+	likeInfo := lipgloss.NewStyle().Foreground(likedColor).Render(likedIndicator)
+	if selectedTrack.rating == types.RatingDislike {
+		likeInfo = dimStyle.Render(likedIndicator)
+	}
 
 	return fmt.Sprintf("%s%s%s%s\n%s%s\n",
 		trackInfo,
@@ -218,19 +325,38 @@ func renderNowPlaying(m *Model, currentPosition, TotalDuration time.Duration) st
 	)
 }
 
-func renderPlayerControls() string {
-	key := lipgloss.NewStyle().Foreground(accentColor).Bold(true)
-	sep := dimmerStyle.Render("  │  ")
-	label := lipgloss.NewStyle().Foreground(textSecondary)
+type playerControl struct {
+	icon, name string
+	pane       keymap.Pane
+	action     keymap.Action
+}
+
+// playerControls are the buttons under the progress bar, they can be clicked
+var playerControls = []playerControl{
+	{"⏮", "prev", keymap.Player, keymap.PreviousTrack},
+	{"⏯", "play/pause", keymap.Player, keymap.PlayPause},
+	{"⏭", "next", keymap.Player, keymap.NextTrack},
+	{"♥", "like", keymap.Global, keymap.LikePlaying},
+	{"✕", "quit", keymap.Global, keymap.Quit},
+	{"📝", "lyrics", keymap.Global, keymap.ToggleLyrics},
+	{"?", "keys", keymap.Global, keymap.Help},
+}
+
+const playerControlSeparator = "  │  "
+
+func renderPlayerControl(m *Model, control playerControl) string {
+	part := lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render(control.icon) +
+		lipgloss.NewStyle().Foreground(textSecondary).Render(" "+control.name)
+	if bound := m.Keys.Key(control.pane, control.action); bound != "" {
+		part += dimmerStyle.Render("(" + bound + ")")
+	}
+	return part
+}
 
+func renderPlayerControls(m *Model) string {
 	var parts []string
-	parts = append(parts,
-		key.Render("⏮")+label.Render(" prev")+dimmerStyle.Render("(b)"),
-		key.Render("⏯")+label.Render(" play/pause")+dimmerStyle.Render("(space)"),
-		key.Render("⏭")+label.Render(" next")+dimmerStyle.Render("(n)"),
-		key.Render("♥")+label.Render(" like")+dimmerStyle.Render("(l)"),
-		key.Render("✕")+label.Render(" quit")+dimmerStyle.Render("(q)"),
-		key.Render("📝")+label.Render(" lyrics")+dimmerStyle.Render("(ctrl+l)"),
-	)
-	return strings.Join(parts, sep)
+	for _, control := range playerControls {
+		parts = append(parts, renderPlayerControl(m, control))
+	}
+	return strings.Join(parts, dimmerStyle.Render(playerControlSeparator))
 }
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+// results asked from the backend per page of a search tab
+const searchPageSize = 20
+
+type searchTabResults struct {
+	Items   []list.Item
+	Index   int
+	HasMore bool
+	Loaded  bool
+}
+
+// SpotifySearchResult keeps the results of every search tab, so going back to a tab does not search again
+type SpotifySearchResult struct {
+	Tab  types.SearchTab
+	tabs [types.SearchTabCount]searchTabResults
+}
+
+func (s *SpotifySearchResult) current() *searchTabResults {
+	return &s.tabs[s.Tab]
+}
+
+func (m Model) search(query string, tab types.SearchTab, offset int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		searchResults, err := m.YtMusicClient.GetSearchResults(ctx, &musicpb.GetSearchResultsRequest{
+			Query:  query,
+			Filter: tab.Filter(),
+			Limit:  searchPageSize,
+			Offset: int32(offset),
+		})
+
+		if err != nil {
+			slog.Error(err.Error())
+			return types.SpotifySearchResultMsg{
+				Query:  query,
+				Tab:    tab,
+				Offset: offset,
+				Result: nil,
+				Err:    err,
+			}
+		}
+
+		var items []list.Item
+		for _, s := range searchResults.Songs {
+			track := types.MapSearchResultSongToTrack(s)
+			items = append(items, track)
+		}
+		for _, v := range searchResults.Videos {
+			items = append(items, types.MapSearchResultSongToTrack(v))
+		}
+		for _, a := range searchResults.Artists {
+			artist := types.Artist{
+				ID:     a.BrowseId,
+				Name:   a.Name,
+				Images: types.MapThumbnailsToImages(a.Thumbnails),
+			}
+			items = append(items, artist)
+		}
+		for _, p := range searchResults.Playlists {
+			playlist := types.Playlist{
+				ID:          p.BrowseId,
+				Name:        p.Title,
+				Description: p.ItemCount,
+				Images:      types.MapThumbnailsToImages(p.Thumbnails),
+				Author:      p.Author,
+			}
+			items = append(items, playlist)
+		}
+		for _, al := range searchResults.Albums {
+			album := types.Album{
+				ID:      al.BrowseId,
+				Name:    al.Title,
+				Artists: types.MapArtistsToArtists(al.Artists),
+				Images:  types.MapThumbnailsToImages(al.Thumbnails),
+				Year:    al.Year,
+				Type:    al.Type,
+			}
+			items = append(items, album)
+		}
+
+		return types.SpotifySearchResultMsg{
+			Query:   query,
+			Tab:     tab,
+			Offset:  offset,
+			HasMore: searchResults.HasMore,
+			Result:  &types.SearchResponse{Items: items},
+			Err:     nil,
+		}
+	}
+}
+
+// startSearch searches the query in the tab that is open, the results of the other tabs load when they are opened
+func (m Model) startSearch(query string) (Model, tea.Cmd) {
+	m.SearchQuery = query
+	m.SearchTabs = SpotifySearchResult{Tab: m.SearchTabs.Tab}
+	return m, tea.Batch(SendLoadingCmd(), m.search(query, m.SearchTabs.Tab, 0))
+}
+
+func (m Model) handleSearchResultMsg(msg types.SpotifySearchResultMsg) (Model, tea.Cmd) {
+	if msg.Offset > 0 {
+		m.IsOnPagination = false
+	}
+	// a newer search was started while this one was in flight
+	if msg.Query != m.SearchQuery {
+		return m, nil
+	}
+	m.IsSearchLoading = false
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	if msg.Result == nil {
+		return m, nil
+	}
+	results := &m.SearchTabs.tabs[msg.Tab]
+	if msg.Offset == 0 {
+		*results = searchTabResults{}
+	}
+	results.Items = append(results.Items, msg.Result.Items...)
+	results.HasMore = msg.HasMore
+	results.Loaded = true
+	if msg.Tab != m.SearchTabs.Tab {
+		return m, nil
+	}
+	if msg.Offset > 0 {
+		index := m.SearchResult.Index()
+		cmd := m.SearchResult.SetItems(m.visibleItems(results.Items))
+		m.SearchResult.Select(index)
+		return m, cmd
+	}
+	m.FocusedOn = SearchResult
+	m.MainViewMode = SearchResultMode
+	return m.getSearchResultModel(&types.SearchResponse{Items: results.Items})
+}
+
+// switchSearchTab opens the tab at the given distance from the current one, searching it the first time it is opened
+func (m Model) switchSearchTab(delta int) (Model, tea.Cmd) {
+	if m.SearchQuery == "" {
+		return m, nil
+	}
+	m.SearchTabs.current().Index = m.SearchResult.Index()
+	count := int(types.SearchTabCount)
+	m.SearchTabs.Tab = types.SearchTab(((int(m.SearchTabs.Tab)+delta)%count + count) % count)
+	results := m.SearchTabs.current()
+	if !results.Loaded {
+		m.SearchResult.SetItems(nil)
+		return m, tea.Batch(SendLoadingCmd(), m.search(m.SearchQuery, m.SearchTabs.Tab, 0))
+	}
+	cmd := m.SearchResult.SetItems(m.visibleItems(results.Items))
+	m.SearchResult.Select(results.Index)
+	return m, cmd
+}
+
+// loadMoreSearchResults loads the next page of the open tab once the selection gets close to the end of the list
+func (m Model) loadMoreSearchResults() (Model, tea.Cmd) {
+	results := m.SearchTabs.current()
+	if !results.HasMore || m.IsOnPagination || m.SearchResult.Index()+5 < len(m.SearchResult.Items()) {
+		return m, nil
+	}
+	m.IsOnPagination = true
+	return m, m.search(m.SearchQuery, m.SearchTabs.Tab, len(results.Items))
+}
+
+func renderSearchTabs(m *Model, width int) string {
+	active := lipgloss.NewStyle().Foreground(accentColor).Bold(true).Underline(true)
+	var tabs []string
+	for tab := types.SearchAllTab; tab < types.SearchTabCount; tab++ {
+		if tab == m.SearchTabs.Tab {
+			tabs = append(tabs, active.Render(tab.String()))
+			continue
+		}
+		tabs = append(tabs, dimStyle.Render(tab.String()))
+	}
+	status := fmt.Sprintf("%d results", len(m.SearchResult.Items()))
+	if m.SearchTabs.current().HasMore {
+		status += ", more load as you scroll"
+	}
+	hints := joinHints(
+		keyHint(m, keymap.SearchResults, keymap.PreviousTab, "previous tab"),
+		keyHint(m, keymap.SearchResults, keymap.NextTab, "next tab"),
+	)
+	return lipgloss.NewStyle().Width(width).Padding(0, 0, 0, 2).Render(lipgloss.JoinVertical(lipgloss.Left,
+		strings.Join(tabs, dimmerStyle.Render("  │  ")),
+		dimmerStyle.Render(status)+dimmerStyle.Render("  │  ")+hints,
+	))
+}
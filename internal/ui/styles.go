@@ -2,44 +2,103 @@ package ui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/kumneger0/clispot/internal/theme"
 )
 
+// the palette and the borders of the theme in use, ApplyTheme replaces them
 var (
-	accentColor    = lipgloss.Color("#7D56F4")
-	accentDim      = lipgloss.Color("#5A3DB5")
-	textPrimary    = lipgloss.Color("#E4E4E7")
-	textSecondary  = lipgloss.Color("#A1A1AA")
-	textDim        = lipgloss.Color("#71717A")
-	borderFocused  = lipgloss.Color("#7D56F4")
-	borderNormal   = lipgloss.Color("#3F3F46")
-	bgSelected     = lipgloss.Color("#7D56F4")
-	fgSelected     = lipgloss.Color("#FAFAFA")
-	progressFilled = lipgloss.Color("#7D56F4")
-	progressEmpty  = lipgloss.Color("#3F3F46")
-	playerFg       = lipgloss.Color("#E4E4E7")
+	accentColor      lipgloss.TerminalColor
+	accentDim        lipgloss.TerminalColor
+	textPrimary      lipgloss.TerminalColor
+	textSecondary    lipgloss.TerminalColor
+	textDim          lipgloss.TerminalColor
+	borderFocused    lipgloss.TerminalColor
+	borderNormal     lipgloss.TerminalColor
+	bgSelected       lipgloss.TerminalColor
+	fgSelected       lipgloss.TerminalColor
+	selectedSubtitle lipgloss.TerminalColor
+	progressFilled   lipgloss.TerminalColor
+	progressEmpty    lipgloss.TerminalColor
+	playerFg         lipgloss.TerminalColor
+	likedColor       lipgloss.TerminalColor
+	paneBorder       lipgloss.Border
+	separatorBorder  lipgloss.Border
 )
 
 var (
+	normalStyle   lipgloss.Style
+	selectedStyle lipgloss.Style
+	dimStyle      lipgloss.Style
+	dimmerStyle   lipgloss.Style
+	titleStyle    lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(theme.Dark)
+}
+
+// ApplyTheme switches the colours and the borders every view is drawn with
+func ApplyTheme(t theme.Theme) {
+	color := func(name string) lipgloss.TerminalColor {
+		hex := t.Colors[name]
+		ansi, ok := t.ANSI[name]
+		if !ok {
+			// lipgloss approximates the colour on terminals with 16 colours
+			ansi = hex
+		}
+		return lipgloss.CompleteColor{TrueColor: hex, ANSI256: hex, ANSI: ansi}
+	}
+	accentColor = color("accent")
+	accentDim = color("accent-dim")
+	textPrimary = color("text")
+	textSecondary = color("text-secondary")
+	textDim = color("text-dim")
+	borderFocused = color("border-focused")
+	borderNormal = color("border")
+	bgSelected = color("selected-bg")
+	fgSelected = color("selected-fg")
+	selectedSubtitle = color("selected-subtitle")
+	progressFilled = color("progress-filled")
+	progressEmpty = color("progress-empty")
+	playerFg = color("player-fg")
+	likedColor = color("liked")
+	paneBorder = borderByName(t.Border)
+	separatorBorder = borderByName(t.Separator)
+
 	normalStyle = lipgloss.NewStyle().
-			Foreground(textPrimary)
+		Foreground(textPrimary)
 
 	selectedStyle = lipgloss.NewStyle().
-			Foreground(fgSelected).
-			Background(bgSelected).
-			Bold(true)
+		Foreground(fgSelected).
+		Background(bgSelected).
+		Bold(true)
 
 	dimStyle = lipgloss.NewStyle().
-			Foreground(textSecondary)
+		Foreground(textSecondary)
 
 	dimmerStyle = lipgloss.NewStyle().
-			Foreground(textDim)
+		Foreground(textDim)
 
 	titleStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
-)
+		Foreground(accentColor).
+		Bold(true)
+}
+
+func borderByName(name string) lipgloss.Border {
+	switch name {
+	case "normal":
+		return lipgloss.NormalBorder()
+	case "thick":
+		return lipgloss.ThickBorder()
+	case "double":
+		return lipgloss.DoubleBorder()
+	case "hidden":
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
 
-func getBorderColor(isFocused bool) lipgloss.Color {
+func getBorderColor(isFocused bool) lipgloss.TerminalColor {
 	if isFocused {
 		return borderFocused
 	}
@@ -47,7 +106,8 @@ func getBorderColor(isFocused bool) lipgloss.Color {
 }
 
 func getPlayerStyles(m *Model, dims layoutDimensions) lipgloss.Style {
-	width := dims.mainWidth + (dims.sidebarWidth*2 + 2)
+	// the player spans the panes above it whichever of them are shown
+	width := m.Width + 2
 	inputStyle := getStyle(m, dims.inputHeight, width, Player)
 	return inputStyle
 }
@@ -59,8 +119,8 @@ func getStyle(m *Model, height, width int, focusedOn FocusedOn) lipgloss.Style {
 			Height(height).
 			Padding(1, 0, 0, 0)
 	}
-	isFocused := m.FocusedOn == focusedOn
-	border := lipgloss.RoundedBorder()
+	isFocused := m.FocusedOn == focusedOn || (focusedOn == MainView && m.FocusedOn == PlaylistPickerView)
+	border := paneBorder
 	style := lipgloss.NewStyle().
 		Width(width).
 		Height(height).
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/types"
+)
+
+const (
+	// the suggestions are only asked for once the user stops typing for this long
+	suggestionsDebounce = 250 * time.Millisecond
+	maxSuggestions      = 8
+)
+
+// SearchSuggestions is the dropdown under the search bar and the position in the search history
+type SearchSuggestions struct {
+	Items []string
+	// index of the highlighted suggestion, -1 when none is
+	cursor int
+	// bumped on every edit so only the last debounce tick asks the backend
+	seq int
+	// how many searches back up/down have walked the history, 0 while the query is being typed
+	historyIndex int
+	// the query that was being typed before walking the history
+	draft string
+}
+
+func (s *SearchSuggestions) close() {
+	s.Items = nil
+	s.cursor = -1
+}
+
+func (s *SearchSuggestions) isOpen() bool {
+	return len(s.Items) > 0
+}
+
+// searchValueChanged is called after the search input was edited, it stops walking the history and
+// schedules the suggestions for the new query
+func (m *Model) searchValueChanged() tea.Cmd {
+	m.Suggestions.historyIndex = 0
+	m.Suggestions.seq++
+	query := strings.TrimSpace(m.Search.Value())
+	if query == "" {
+		m.Suggestions.close()
+		return nil
+	}
+	seq := m.Suggestions.seq
+	return tea.Tick(suggestionsDebounce, func(time.Time) tea.Msg {
+		return types.SearchSuggestionsDebounceMsg{Seq: seq, Query: query}
+	})
+}
+
+func (m Model) handleSuggestionsDebounceMsg(msg types.SearchSuggestionsDebounceMsg) (Model, tea.Cmd) {
+	if msg.Seq != m.Suggestions.seq || m.FocusedOn != SearchBar {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := m.YtMusicClient.GetSearchSuggestions(ctx, &musicpb.GetSearchSuggestionsRequest{Query: msg.Query})
+		if err != nil {
+			slog.Error(err.Error())
+			return types.SearchSuggestionsMsg{Query: msg.Query, Err: err}
+		}
+		return types.SearchSuggestionsMsg{Query: msg.Query, Suggestions: resp.Suggestions}
+	}
+}
+
+func (m Model) handleSuggestionsMsg(msg types.SearchSuggestionsMsg) (Model, tea.Cmd) {
+	// failing suggestions are not worth an alert while the user is typing, searching still works
+	if msg.Err != nil || msg.Query != strings.TrimSpace(m.Search.Value()) || m.FocusedOn != SearchBar {
+		return m, nil
+	}
+	suggestions := msg.Suggestions
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	m.Suggestions.Items = suggestions
+	m.Suggestions.cursor = -1
+	return m, nil
+}
+
+// walkSearchHistory replaces the query with an older (up) or newer (down) search, going past the
+// newest search brings back what was being typed
+func (m Model) walkSearchHistory(older bool) Model {
+	index := m.Suggestions.historyIndex
+	if older {
+		index++
+	} else {
+		index--
+	}
+	if index < 0 {
+		return m
+	}
+	if index == 0 {
+		m.Suggestions.historyIndex = 0
+		m.Search.SetValue(m.Suggestions.draft)
+		m.Search.CursorEnd()
+		return m
+	}
+	query, ok := m.SearchHistory.Recent(index)
+	if !ok {
+		return m
+	}
+	if m.Suggestions.historyIndex == 0 {
+		m.Suggestions.draft = m.Search.Value()
+	}
+	m.Suggestions.historyIndex = index
+	m.Search.SetValue(query)
+	m.Search.CursorEnd()
+	return m
+}
+
+// handleSearchBarKey moves through the suggestions while the dropdown is open and through the search
+// history otherwise, ok is false for keys that are handled elsewhere
+func (m Model) handleSearchBarKey(key string) (Model, tea.Cmd, bool) {
+	switch key {
+	case "up", "down":
+		if !m.Suggestions.isOpen() {
+			return m.walkSearchHistory(key == "up"), nil, true
+		}
+		if key == "down" {
+			m.Suggestions.cursor = min(m.Suggestions.cursor+1, len(m.Suggestions.Items)-1)
+		} else {
+			m.Suggestions.cursor = max(m.Suggestions.cursor-1, -1)
+		}
+		return m, nil, true
+	case "esc":
+		if !m.Suggestions.isOpen() {
+			return m, nil, false
+		}
+		m.Suggestions.seq++
+		m.Suggestions.close()
+		return m, nil, true
+	case "enter":
+		if m.Suggestions.isOpen() && m.Suggestions.cursor >= 0 {
+			m.Search.SetValue(m.Suggestions.Items[m.Suggestions.cursor])
+			m.Search.CursorEnd()
+		}
+		// a tick still in flight must not reopen the dropdown over the results
+		m.Suggestions.seq++
+		m.Suggestions.close()
+		m.Suggestions.historyIndex = 0
+		if err := m.SearchHistory.Add(m.Search.Value()); err != nil {
+			slog.Error(err.Error())
+		}
+		model, cmd := m.handleEnterKey()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+func renderSuggestions(m *Model, width int) string {
+	var rows []string
+	for i, suggestion := range m.Suggestions.Items {
+		if i == m.Suggestions.cursor {
+			rows = append(rows, lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render("› "+suggestion))
+			continue
+		}
+		rows = append(rows, normalStyle.Render("  "+suggestion))
+	}
+	key := lipgloss.NewStyle().Foreground(accentColor).Bold(true)
+	rows = append(rows, strings.Join([]string{
+		key.Render("↑↓") + dimmerStyle.Render(" pick"),
+		key.Render("enter") + dimmerStyle.Render(" search"),
+		key.Render("esc") + dimmerStyle.Render(" close"),
+	}, dimmerStyle.Render("  │  ")))
+	return lipgloss.NewStyle().
+		Width(width).
+		Padding(0, 1).
+		BorderBottom(true).
+		BorderStyle(separatorBorder).
+		BorderForeground(borderNormal).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/filter"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/types"
+	"go.dalton.dog/bubbleup"
+)
+
+const radioLimit = 50
+
+// TrackInfoPage is the detail view of a single track, esc goes back to the view it was opened from
+type TrackInfoPage struct {
+	Track types.Track
+	// looked up once when the page opens, HasTrack scans the whole metadata cache
+	cached              bool
+	previousMode        MainViewMode
+	previousBreadcrumbs []types.Breadcrumb
+}
+
+// trackCache is implemented by the metadata cache wrapping the backend client
+type trackCache interface {
+	HasTrack(videoID string) bool
+}
+
+func trackURL(track types.Track) string {
+	return "https://music.youtube.com/watch?v=" + track.ID
+}
+
+func (m *Model) isTrackCached(track types.Track) bool {
+	cache, ok := m.YtMusicClient.(trackCache)
+	return ok && cache.HasTrack(track.ID)
+}
+
+// openTrackInfo shows the highlighted track, or the playing one when no track list is focused
+func (m Model) openTrackInfo() (Model, tea.Cmd) {
+	track, ok := m.highlightedTrack()
+	if !ok && m.SelectedTrack != nil && m.SelectedTrack.Track != nil {
+		track, ok = m.SelectedTrack.Track.Track, true
+	}
+	if !ok || track.ID == "" {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "select a track to see its details")
+	}
+	if m.MainViewMode == TrackInfoMode {
+		m.TrackInfo.Track = track
+		m.TrackInfo.cached = m.isTrackCached(track)
+		return m, nil
+	}
+	m.TrackInfo = TrackInfoPage{
+		Track:               track,
+		cached:              m.isTrackCached(track),
+		previousMode:        m.MainViewMode,
+		previousBreadcrumbs: m.BreadcrumbItems,
+	}
+	m.MainViewMode = TrackInfoMode
+	m.FocusedOn = MainView
+	m.BreadcrumbItems = append(append([]types.Breadcrumb{}, m.BreadcrumbItems...), types.Breadcrumb{Name: track.Name, Icon: "♫"})
+	updateDelegate(&m)
+	return m, nil
+}
+
+func (m Model) closeTrackInfo() Model {
+	m.MainViewMode = m.TrackInfo.previousMode
+	m.BreadcrumbItems = m.TrackInfo.previousBreadcrumbs
+	m.TrackInfo = TrackInfoPage{}
+	updateDelegate(&m)
+	return m
+}
+
+// copyToClipboard falls back to an OSC 52 escape sequence so copying works over ssh and without xclip
+func copyToClipboard(text string) error {
+	if err := clipboard.WriteAll(text); err == nil {
+		return nil
+	}
+	_, err := osc52.New(text).WriteTo(os.Stderr)
+	return err
+}
+
+func (m Model) copyTrackField(name, value string) (Model, tea.Cmd) {
+	if err := copyToClipboard(value); err != nil {
+		slog.Error(err.Error())
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to copy the "+name+": "+err.Error())
+	}
+	return m, m.Alert.NewAlertCmd(bubbleup.InfoKey, "copied the "+name)
+}
+
+func (m Model) openTrackArtist(track types.Track) (Model, tea.Cmd) {
+	for _, artist := range track.Artists {
+		if artist.ID != "" {
+			return m.openArtist(artist.ID, artist.Name)
+		}
+	}
+	return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "this artist has no page")
+}
+
+func (m Model) openTrackAlbum(track types.Track) (Model, tea.Cmd) {
+	if track.Album.ID == "" {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "this track is not part of an album")
+	}
+	return m.openAlbum(track.Album.ID, track.Album.Name)
+}
+
+func (m Model) startRadio(track types.Track) (Model, tea.Cmd) {
+	cmd := func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		radio, err := m.YtMusicClient.GetRadio(ctx, &musicpb.GetRadioRequest{
+			VideoId: track.ID,
+			Limit:   radioLimit,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return types.RadioLoadedMsg{Track: track, Err: err}
+		}
+		var tracks []types.Track
+		for _, song := range radio.Tracks {
+			tracks = append(tracks, types.MapSongToTrack(song))
+		}
+		return types.RadioLoadedMsg{Track: track, Tracks: tracks}
+	}
+	return m, cmd
+}
+
+func (m Model) handleRadioLoadedMsg(msg types.RadioLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, "failed to start the radio: "+msg.Err.Error())
+	}
+	if len(msg.Tracks) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "no radio for "+msg.Track.Name)
+	}
+	var tracks []types.PlaylistTrackObject
+	for _, track := range filter.Visible(m.Filter, msg.Tracks, func(track types.Track) types.Track { return track }) {
+		tracks = append(tracks, types.PlaylistTrackObject{Track: track})
+	}
+	if len(tracks) == 0 {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "every track of the radio is hidden by the content filter")
+	}
+	model, cmd := m.playTracks(tracks, 0, "start radio")
+	return model, tea.Batch(cmd, m.Alert.NewAlertCmd(bubbleup.InfoKey, "radio started from "+msg.Track.Name))
+}
+
+// handleTrackInfoKey handles the actions of the track info view, ok is false for keys that are handled elsewhere
+func (m Model) handleTrackInfoKey(action keymap.Action) (Model, tea.Cmd, bool) {
+	track := m.TrackInfo.Track
+	var model Model
+	var cmd tea.Cmd
+	switch action {
+	case keymap.Back:
+		return m.closeTrackInfo(), nil, true
+	case keymap.Select:
+		model, cmd = m.playTracks([]types.PlaylistTrackObject{{Track: track}}, 0, "replace queue")
+	case keymap.CopyURL:
+		model, cmd = m.copyTrackField("url", trackURL(track))
+	case keymap.CopyID:
+		model, cmd = m.copyTrackField("video id", track.ID)
+	case keymap.OpenArtist:
+		model, cmd = m.openTrackArtist(track)
+	case keymap.OpenAlbum:
+		model, cmd = m.openTrackAlbum(track)
+	case keymap.StartRadio:
+		model, cmd = m.startRadio(track)
+	default:
+		return m, nil, false
+	}
+	return model, cmd, true
+}
+
+func renderTrackInfo(m *Model, width int) string {
+	track := m.TrackInfo.Track
+	label := dimStyle.Width(12)
+	value := normalStyle.Width(max(width-16, 10))
+	row := func(name, text string) string {
+		if text == "" {
+			text = "—"
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(name), value.Render(text))
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	var artists []string
+	for _, artist := range track.Artists {
+		artists = append(artists, artist.Name)
+	}
+	var thumbnails []string
+	for _, image := range track.Album.Images {
+		thumbnails = append(thumbnails, fmt.Sprintf("%dx%d %s", image.Width, image.Height, image.URL))
+	}
+	duration := ""
+	if track.DurationMS > 0 {
+		duration = formatTime(time.Duration(track.DurationMS) * time.Millisecond)
+	}
+	cached := "no"
+	if m.TrackInfo.cached {
+		cached = "yes, browsable offline"
+	}
+
+	hints := joinHints(
+		keyHint(m, keymap.Global, keymap.Select, "play"),
+		keyHint(m, keymap.TrackInfo, keymap.StartRadio, "radio"),
+		keyHint(m, keymap.TrackInfo, keymap.OpenArtist, "open artist"),
+		keyHint(m, keymap.TrackInfo, keymap.OpenAlbum, "open album"),
+		keyHint(m, keymap.TrackInfo, keymap.CopyURL, "copy url"),
+		keyHint(m, keymap.TrackInfo, keymap.CopyID, "copy id"),
+		keyHint(m, keymap.TrackInfo, keymap.Back, "back"),
+	)
+
+	return lipgloss.NewStyle().Width(width).Padding(1, 0, 0, 1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(track.Name),
+		hints,
+		"",
+		row("Artists", strings.Join(artists, ", ")),
+		row("Album", track.Album.Name),
+		row("Year", track.Album.Year),
+		row("Duration", duration),
+		row("Explicit", yesNo(track.Explicit)),
+		row("Liked", yesNo(m.IsTrackLiked(track))),
+		row("Disliked", yesNo(m.TrackRating(track) == types.RatingDislike)),
+		row("Cached", cached),
+		row("Video ID", track.ID),
+		row("URL", trackURL(track)),
+		row("Thumbnails", strings.Join(thumbnails, "\n")),
+	))
+}
@@ -17,7 +17,13 @@ import (
 	"github.com/godbus/dbus/v5"
 	"github.com/godbus/dbus/v5/prop"
 	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/filter"
+	"github.com/kumneger0/clispot/internal/history"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/layout"
 	"github.com/kumneger0/clispot/internal/types"
+	"github.com/kumneger0/clispot/internal/undo"
 	"github.com/kumneger0/clispot/internal/youtube"
 	"go.dalton.dog/bubbleup"
 )
@@ -31,6 +37,9 @@ const (
 	SearchBar    FocusedOn = "SEARCH_BAR"
 	QueueList    FocusedOn = "QUEUE_LIST"
 	SearchResult FocusedOn = "SEARCH_RESULT"
+	// PlaylistPickerView is focused while the user chooses (or names) the playlist to save tracks into
+	PlaylistPickerView FocusedOn = "PLAYLIST_PICKER"
+	PromptInput        FocusedOn = "PROMPT"
 )
 
 type MainViewMode string
@@ -42,11 +51,22 @@ const (
 	//at this time the previous are gone b/c i was sharing  this main new to show items in playlist and the search result
 	// so by adding this MainViewMode we can switch b/c modes so that we keep the result in memory
 	// meaning we can switch b/n search result and normal mode
-	NormalMode   MainViewMode = "NORMAL_MODE"
-	LyricsMode   MainViewMode = "LYRICS_MODE"
-	HomePageMode MainViewMode = "HOME_PAGE_MODE"
+	NormalMode         MainViewMode = "NORMAL_MODE"
+	LyricsMode         MainViewMode = "LYRICS_MODE"
+	HomePageMode       MainViewMode = "HOME_PAGE_MODE"
+	PlaylistPickerMode MainViewMode = "PLAYLIST_PICKER_MODE"
+	AlbumMode          MainViewMode = "ALBUM_MODE"
+	ArtistMode         MainViewMode = "ARTIST_MODE"
+	ExploreMode        MainViewMode = "EXPLORE_MODE"
+	TrackInfoMode      MainViewMode = "TRACK_INFO_MODE"
+	BlocklistMode      MainViewMode = "BLOCKLIST_MODE"
 )
 
+// showsTrackList reports whether the main view lists tracks from SelectedPlayListItems
+func (mode MainViewMode) showsTrackList() bool {
+	return mode == NormalMode || mode == AlbumMode
+}
+
 type HomePageViewMode int
 
 const (
@@ -54,13 +74,20 @@ const (
 	HomePageContentView
 )
 
-type SpotifySearchResult struct {
-	Tracks, Artists, Albums, Playlists list.Model
+type SelectedTrack struct {
+	rating types.Rating
+	// set once the play has been written to the playback history so it is only recorded once
+	isRecorded bool
+	// the seconds heard before the last seek and the position the playback went on from, the part a seek skips
+	// is not heard
+	heardBeforeSeek float64
+	playingFrom     float64
+	Track           *types.PlaylistTrackObject
 }
 
-type SelectedTrack struct {
-	isLiked bool
-	Track   *types.PlaylistTrackObject
+// heard is how many seconds of the track were listened to, position is where it plays now
+func (s *SelectedTrack) heard(position float64) float64 {
+	return s.heardBeforeSeek + max(position-s.playingFrom, 0)
 }
 
 type MusicQueueList struct {
@@ -75,12 +102,26 @@ type Model struct {
 	Alert                 bubbleup.AlertModel
 	SelectedPlayListItems list.Model
 	LyricsView            viewport.Model
-	FocusedOn             FocusedOn
+	Lyrics                LyricsPage
+	Keys                  *keymap.KeyMap
+	// the ? overlay listing the key bindings, it takes the keys while it is open
+	ShowHelp bool
+	HelpView viewport.Model
+	// the sidebar and the queue the user showed or hid, see layout.Split
+	paneToggles layout.Toggled
+	// the last row clicked, a second click on it soon after plays it
+	lastClick mouseClick
+	// cover art of the playing track and of the album and artist pages, nil draws none
+	Artwork   *Artwork
+	FocusedOn FocusedOn
 	MainViewMode
-	PlayerProcess       *types.Player
-	playbackCancel      context.CancelFunc
-	SelectedTrack       *SelectedTrack
-	PlayedSeconds       float64
+	PlayerProcess  *types.Player
+	playbackCancel context.CancelFunc
+	SelectedTrack  *SelectedTrack
+	PlayedSeconds  float64
+	// percent of the full volume the tracks play at
+	Volume              int
+	sleep               sleepTimer
 	Height              int
 	Width               int
 	LibraryWidth        int
@@ -94,8 +135,10 @@ type Model struct {
 	//at that time when he selects artist or playlist the search were hidden from mainView
 	//so that if search again we can show the previous result by comparing the query
 	// TODO: find a better way than this looks very ugly
-	SearchQuery string
-	// SearchResult                             *SpotifySearchResult
+	SearchQuery      string
+	SearchTabs       SpotifySearchResult
+	SearchHistory    *history.SearchStore
+	Suggestions      SearchSuggestions
 	IsSearchLoading  bool
 	SearchResult     list.Model
 	PaginationInfo   *types.PaginationInfo
@@ -104,6 +147,27 @@ type Model struct {
 	HomePageData     *musicpb.GetHomePageResponse
 	HomePageList     list.Model
 	HomePageViewMode HomePageViewMode
+	History          *history.Store
+	PlaylistPicker   PlaylistPicker
+	Prompt           Prompt
+	// undo/redo history of the changes made to the queue
+	QueueHistory *undo.Stack[[]list.Item]
+	Library      Library
+	Album        AlbumPage
+	Artist       ArtistPage
+	Explore      ExplorePage
+	TrackInfo    TrackInfoPage
+	// hides and skips explicit and blocked tracks, nil shows everything
+	Filter    *filter.Filter
+	Blocklist list.Model
+	// the playlist shown in the main view, empty when the tracks are not a playlist
+	Playlist PlaylistPage
+	// how many steps back the user went through the playback history with `b`, 0 means not browsing the history
+	historyCursor int
+	// the likes and dislikes made in this session, keyed by track id
+	ratings map[string]types.Rating
+	// set once a later page was appended to the track list, a refresh of the first page would drop it
+	loadedMorePages bool
 }
 
 type Instance struct {
@@ -173,7 +237,8 @@ func renderBreadcrumbs(items []types.Breadcrumb) string {
 	return lipgloss.NewStyle().Padding(0, 0, 0, 1).Render(strings.Join(parts, " "))
 }
 
-func (m Model) View() string {
+// prepareLists sets the titles and hides the parts of the lists the panes do not show
+func (m *Model) prepareLists() {
 	m.SideBarList.Title = "Youtube Music tui"
 	m.MusicQueueList.Model.Title = "Queue"
 	removeListDefaults(&m.SideBarList)
@@ -181,15 +246,58 @@ func (m Model) View() string {
 	removeListDefaults(&m.SearchResult)
 	removeListDefaults(&m.HomePageList)
 	removeListDefaults(&m.MusicQueueList.Model)
+	removeListDefaults(&m.Artist.List)
+	removeListDefaults(&m.Explore.List)
+	removeListDefaults(&m.Blocklist)
 	m.SearchResult.SetShowTitle(false)
 	m.SelectedPlayListItems.SetShowTitle(false)
 	m.HomePageList.SetShowTitle(false)
+}
+
+func renderMainSearchBar(m *Model, width int) string {
+	if m.FocusedOn == PromptInput {
+		return renderPrompt(m, width)
+	}
+	return renderSearchBar(m, width)
+}
+
+// mainList is the list the main pane shows and what is drawn above it, nil for the views without one
+func mainList(m *Model, width int) ([]string, *list.Model) {
+	above := []string{renderMainSearchBar(m, width), renderBreadcrumbs(m.BreadcrumbItems)}
+	switch {
+	case m.ShowHelp, m.IsSearchLoading:
+		return nil, nil
+	case m.MainViewMode == AlbumMode:
+		return append(above, renderAlbumHeader(m, width)), &m.SelectedPlayListItems
+	case m.MainViewMode == ArtistMode:
+		return append(above, renderArtistHeader(m, width)), &m.Artist.List
+	case m.MainViewMode == BlocklistMode:
+		return append(above, renderBlocklistHeader(m, width)), &m.Blocklist
+	case m.MainViewMode == ExploreMode:
+		return append(above, renderExploreHints(m)), &m.Explore.List
+	case m.MainViewMode == HomePageMode:
+		return above, &m.HomePageList
+	case m.MainViewMode == SearchResultMode, m.MainViewMode == LyricsMode, m.MainViewMode == PlaylistPickerMode, m.MainViewMode == TrackInfoMode:
+		return nil, nil
+	case m.isViewingPlaylist():
+		return append(above, renderPlaylistHints(m)), &m.SelectedPlayListItems
+	}
+	return above, &m.SelectedPlayListItems
+}
+
+func searchResultHeader() string {
+	return titleStyle.Render("  Search Results")
+}
+
+func (m Model) View() string {
+	m.prepareLists()
 	dimensions := calculateLayoutDimensions(&m)
-	sideBarView := getStyle(&m, dimensions.contentHeight, dimensions.sidebarWidth, SideView).Render(m.SideBarList.View())
-	searchBar := renderSearchBar(&m, dimensions.mainWidth)
+	searchBar := renderMainSearchBar(&m, dimensions.mainWidth)
 	breadcrumb := renderBreadcrumbs(m.BreadcrumbItems)
 	var mainView string
-	if m.IsSearchLoading {
+	if m.ShowHelp {
+		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(m.HelpView.View())
+	} else if m.IsSearchLoading {
 		loadingText := dimmerStyle.Render("  ⟳ Loading...")
 		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(
 			lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, loadingText),
@@ -198,10 +306,11 @@ func (m Model) View() string {
 		height := dimensions.contentHeight - (dimensions.contentHeight * 10 / 100)
 		width := dimensions.mainWidth - (dimensions.mainWidth * 10 / 100)
 		searchView := getStyle(&m, height, width, SearchResult).Render(m.SearchResult.View())
-		resultHeader := titleStyle.Render("  Search Results")
+		resultHeader := searchResultHeader()
 		searchResultView := lipgloss.JoinVertical(lipgloss.Top,
 			searchBar,
 			resultHeader,
+			renderSearchTabs(&m, width),
 			lipgloss.JoinHorizontal(lipgloss.Top, searchView),
 		)
 		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(searchResultView)
@@ -209,13 +318,19 @@ func (m Model) View() string {
 		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(
 			lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, m.LyricsView.View()),
 		)
-	} else if m.MainViewMode == HomePageMode {
+	} else if m.MainViewMode == PlaylistPickerMode {
+		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(
+			lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, renderPlaylistPicker(&m)),
+		)
+	} else if m.MainViewMode == TrackInfoMode {
 		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(
-			lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, lipgloss.NewStyle().Padding(1, 0, 0, 0).Render(m.HomePageList.View())),
+			lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, renderTrackInfo(&m, dimensions.mainWidth)),
 		)
 	} else {
-		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).
-			Render(lipgloss.JoinVertical(lipgloss.Top, searchBar, breadcrumb, lipgloss.NewStyle().Padding(1, 0, 0, 0).Render(m.SelectedPlayListItems.View())))
+		above, mainListModel := mainList(&m, dimensions.mainWidth)
+		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, MainView).Render(
+			lipgloss.JoinVertical(lipgloss.Top, append(above, lipgloss.NewStyle().Padding(1, 0, 0, 0).Render(mainListModel.View()))...),
+		)
 	}
 
 	var playingView string
@@ -227,15 +342,32 @@ func (m Model) View() string {
 		playingView = renderNowPlaying(&m, currentPosition, total)
 	}
 
-	controls := renderPlayerControls()
+	controls := renderPlayerControls(&m)
 	playingCombined := strings.TrimSpace(playingView) + "\n" + controls
+	if art := nowPlayingArtwork(&m); art != "" {
+		playingCombined = lipgloss.JoinHorizontal(lipgloss.Top, art, " ", playingCombined)
+	}
 
 	playing := getPlayerStyles(&m, dimensions).
 		Foreground(playerFg).
 		Render(playingCombined)
-	queueList := getStyle(&m, dimensions.contentHeight, dimensions.sidebarWidth, QueueList).Render(m.MusicQueueList.View())
+	// an open overlay takes the place of the main pane, the help is still drawn over it
+	var panes []string
+	switch {
+	case dimensions.sidebar == layout.Docked:
+		panes = append(panes, getStyle(&m, dimensions.contentHeight, dimensions.sidebarWidth, SideView).Render(m.SideBarList.View()))
+	case dimensions.sidebar == layout.Overlay && !m.ShowHelp:
+		mainView = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, SideView).Render(m.SideBarList.View())
+	}
+	panes = append(panes, mainView)
+	switch {
+	case dimensions.queue == layout.Docked:
+		panes = append(panes, getStyle(&m, dimensions.contentHeight, dimensions.queueWidth, QueueList).Render(m.MusicQueueList.View()))
+	case dimensions.queue == layout.Overlay && !m.ShowHelp:
+		panes[len(panes)-1] = getStyle(&m, dimensions.contentHeight, dimensions.mainWidth, QueueList).Render(m.MusicQueueList.View())
+	}
 	combinedView := lipgloss.JoinVertical(lipgloss.Top,
-		lipgloss.JoinHorizontal(lipgloss.Top, sideBarView, mainView, queueList),
+		lipgloss.JoinHorizontal(lipgloss.Top, panes...),
 		playing,
 	)
 	return m.Alert.Render(combinedView)
@@ -250,29 +382,35 @@ func formatTime(d time.Duration) string {
 
 type layoutDimensions struct {
 	sidebarWidth  int
+	queueWidth    int
 	mainWidth     int
 	contentHeight int
 	inputHeight   int
+	sidebar       layout.Mode
+	queue         layout.Mode
 }
 
 func calculateLayoutDimensions(m *Model) layoutDimensions {
-	sidebarWidth := m.Width * 22 / 100
 	inputHeight := min(max(m.Height*10/100, 2), 3)
-	mainCenterArea := (m.Width - (sidebarWidth * 2))
+	panes := layout.Split(m.Width+windowMargin, m.Width, config.GetConfig().Layout, m.paneToggles)
 
 	return layoutDimensions{
-		sidebarWidth:  sidebarWidth,
-		mainWidth:     mainCenterArea,
+		sidebarWidth:  panes.SidebarWidth,
+		queueWidth:    panes.QueueWidth,
+		mainWidth:     panes.MainWidth,
 		contentHeight: m.Height * 90 / 100,
 		inputHeight:   inputHeight,
+		sidebar:       panes.Sidebar,
+		queue:         panes.Queue,
 	}
 }
 
 func removeListDefaults(listToRemoveDefaults *list.Model) {
 	if listToRemoveDefaults != nil {
-		listToRemoveDefaults.SetShowFilter(false)
+		// the "/" filter input and the number of matches are only shown while a filter is typed or applied
+		listToRemoveDefaults.SetShowFilter(listToRemoveDefaults.FilterState() == list.Filtering)
 		listToRemoveDefaults.SetShowPagination(false)
 		listToRemoveDefaults.SetShowHelp(false)
-		listToRemoveDefaults.SetShowStatusBar(false)
+		listToRemoveDefaults.SetShowStatusBar(listToRemoveDefaults.FilterState() != list.Unfiltered)
 	}
 }
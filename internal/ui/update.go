@@ -3,13 +3,19 @@ package ui
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/godbus/dbus/v5"
 	musicpb "github.com/kumneger0/clispot/gen"
+	"github.com/kumneger0/clispot/internal/config"
+	"github.com/kumneger0/clispot/internal/history"
+	"github.com/kumneger0/clispot/internal/keymap"
+	"github.com/kumneger0/clispot/internal/listfilter"
 	"github.com/kumneger0/clispot/internal/types"
 	"github.com/kumneger0/clispot/internal/youtube"
 	"go.dalton.dog/bubbleup"
@@ -32,7 +38,7 @@ func getMusicMetadata(music MusicMetadata) map[string]any {
 }
 
 func (m Model) getSearchResultModel(searchResponse *types.SearchResponse) (Model, tea.Cmd) {
-	m.SearchResult = list.New(searchResponse.Items, CustomDelegate{Model: &m}, 10, 20)
+	m.SearchResult = list.New(m.visibleItems(searchResponse.Items), CustomDelegate{Model: &m}, 10, 20)
 	return m, nil
 }
 
@@ -50,41 +56,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			alertCmd := m.Alert.NewAlertCmd(bubbleup.ErrorKey, "Health Check Error")
 			return m, alertCmd
 		}
-		homePageFeed := func() tea.Msg {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			homePage, err := m.YtMusicClient.GetHomePage(ctx, &musicpb.GetHomePageRequest{})
-			if err != nil {
-				slog.Error(err.Error())
-				return types.HomePageResponseMsg{
-					Response: nil,
-					Err:      err,
-				}
-			}
-			return types.HomePageResponseMsg{
-				Response: homePage,
-				Err:      nil,
-			}
-		}
-		cmds = append(cmds, SendLoadingCmd(), homePageFeed)
-	case types.PlaylistDetailMsg:
-		if msg.Err != nil {
-			slog.Error(msg.Err.Error())
-			alertCmd := m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
-			return m, alertCmd
-		}
-		var tracks []*types.PlaylistTrackObject
-		for _, track := range msg.Playlist.Tracks {
-			tracks = append(tracks, &types.PlaylistTrackObject{
-				Track: types.MapSongToTrack(track),
-			})
-		}
-		cmd := func() tea.Msg {
-			return types.UpdatePlaylistMsg{
-				Playlist: tracks,
-			}
-		}
-		return m, cmd
+		cmds = append(cmds, SendLoadingCmd(), m.getHomePage())
 	case types.UpdateHomePageContentMsg:
 		var items []list.Item
 		contents := m.HomePageData.Sections[msg.Item.Index]
@@ -126,13 +98,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			})
 			if err != nil {
 				return types.CheckUserSavedTrackResponseMsg{
-					Saved: false,
-					Err:   err,
+					TrackID: msg.VideoID,
+					Saved:   false,
+					Err:     err,
 				}
 			}
 			return types.CheckUserSavedTrackResponseMsg{
-				Saved: resp.IsSaved,
-				Err:   err,
+				TrackID: msg.VideoID,
+				Saved:   resp.IsSaved,
+				Err:     err,
 			}
 		}
 		cmds = append(cmds, likedCmd)
@@ -143,24 +117,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			alertCmd := m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
 			return m, alertCmd
 		}
-		m.SelectedTrack.isLiked = msg.Saved
+		m.setTrackSaved(msg.TrackID, msg.Saved)
 		return m, nil
 	case types.SearchingMsg:
 		m.IsSearchLoading = true
+	case types.SearchSuggestionsDebounceMsg:
+		return m.handleSuggestionsDebounceMsg(msg)
+	case types.SearchSuggestionsMsg:
+		return m.handleSuggestionsMsg(msg)
+	case types.LyricsLoadedMsg:
+		return m.handleLyricsLoadedMsg(msg)
+	case types.ArtworkLoadedMsg:
+		return m, m.Artwork.loaded(msg, shownArtwork(&m))
+	case types.SleepTimerMsg:
+		return m.handleSleepTimerMsg(msg)
 	case types.SpotifySearchResultMsg:
-		var alertCmd tea.Cmd
-		if msg.Err != nil {
-			alertCmd = m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
-			cmds = append(cmds, alertCmd)
-		}
-		if msg.Result != nil {
-			m.FocusedOn = SearchResult
-			m.MainViewMode = SearchResultMode
-			model, cmd := m.getSearchResultModel(msg.Result)
-			m = model
-			cmds = append(cmds, cmd)
-			m.IsSearchLoading = false
-		}
+		model, cmd := m.handleSearchResultMsg(msg)
+		m = model
+		cmds = append(cmds, cmd)
 	case types.HomePageResponseMsg:
 		var alertCmd tea.Cmd
 		m.IsSearchLoading = false
@@ -185,22 +159,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.HomePageViewMode = HomePageSectionView
 		m.MainViewMode = HomePageMode
 		return m, nil
-	case *types.UserFollowedArtistResponse:
-		// TODO: implement later
+	case types.UserPlaylistsMsg:
+		return m.handleUserPlaylistsMsg(msg)
+	case types.PlaylistSavedMsg:
+		return m.handlePlaylistSavedMsg(msg)
+	case types.PlaylistExportedMsg:
+		return m.handlePlaylistExportedMsg(msg)
+	case types.PlaylistImportedMsg:
+		return m.handlePlaylistImportedMsg(msg)
+	case types.ArtistDetailMsg:
+		return m.handleArtistDetailMsg(msg)
+	case types.RadioLoadedMsg:
+		return m.handleRadioLoadedMsg(msg)
+	case types.CacheRefreshedMsg:
+		return m.handleCacheRefreshedMsg(msg)
+	case types.ExploreLoadedMsg:
+		return m.handleExploreLoadedMsg(msg)
+	case types.ArtistFollowMsg:
+		return m.handleArtistFollowMsg(msg)
+	case types.AlbumDetailMsg:
+		return m.handleAlbumDetailMsg(msg)
+	case types.LibraryGroupLoadedMsg:
+		return m.handleLibraryGroupLoadedMsg(msg)
 	case types.DBusMessage:
 		model, cmd := m.handleDbusMessage(msg.MessageType, cmds)
 		m = model
 		cmds = append(cmds, cmd)
-	case types.LikeUnlikeTrackMsg:
-		if msg.TrackID == m.SelectedTrack.Track.Track.ID {
-			m.SelectedTrack.isLiked = msg.Like
-		}
+	case types.RateTrackMsg:
+		return m.handleRateTrackMsg(msg)
+	case types.PlaylistItemsRemovedMsg:
+		return m.handlePlaylistItemsRemovedMsg(msg)
+	case types.PlaylistEditedMsg:
+		return m.handlePlaylistEditedMsg(msg)
+	case types.PlaylistDeletedMsg:
+		return m.handlePlaylistDeletedMsg(msg)
 	case types.PlayedSecondsUpdateMsg:
 		if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
 			return m, nil
 		}
 		m.PlayedSeconds = msg.CurrentSeconds
+		if m.MainViewMode == LyricsMode {
+			m.followLyrics()
+		}
 		totalDurationInSeconds := m.SelectedTrack.Track.Track.DurationMS / 1000
+		if !m.SelectedTrack.isRecorded && history.ShouldRecord(m.SelectedTrack.heard(m.PlayedSeconds), float64(totalDurationInSeconds)) {
+			m.SelectedTrack.isRecorded = true
+			if err := m.History.Add(m.SelectedTrack.Track.Track, time.Now()); err != nil {
+				slog.Error(err.Error())
+			}
+		}
 		if (float64(totalDurationInSeconds) - (m.PlayedSeconds)) < 1 {
 			m.PlayedSeconds = 0
 			model, cmd := m.handleMusicChange(true, false)
@@ -209,71 +216,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.WindowSizeMsg:
-		m.Width = msg.Width - 4
-		m.Height = msg.Height - 4
+		m = m.resize(msg.Width, msg.Height)
 		dims := calculateLayoutDimensions(&m)
 		m.LibraryWidth = dims.sidebarWidth
 		m.MainViewWidth = dims.mainWidth
 		m.PlayerSectionHeight = dims.inputHeight
-		m.LyricsView.Width = dims.mainWidth
-		m.LyricsView.Height = dims.contentHeight
-		return m, nil
-	case types.UpdatePlaylistMsg:
-		if msg.Playlist != nil {
-			var playListItemSongs []list.Item
-			for _, item := range msg.Playlist {
-				if msg.ShouldAppendQueue {
-					item.IsItFromQueue = true
-				}
-				playListItemSongs = append(playListItemSongs, *item)
-			}
-			m.MainViewMode = NormalMode
-			m.IsSearchLoading = false
-			var currentItems []list.Item
-			if msg.ShouldAppendQueue && m.MusicQueueList != nil {
-				currentItems = m.MusicQueueList.Items()
-			} else {
-				currentItems = m.SelectedPlayListItems.Items()
-			}
-			if msg.ShouldAppend {
-				playListItemSongs = append(currentItems, playListItemSongs...)
-				m.IsOnPagination = false
-			}
-
-			var cmd tea.Cmd
-			if msg.ShouldAppendQueue {
-				if m.MusicQueueList != nil {
-					return m, nil
-				}
-				cmd = m.MusicQueueList.SetItems(playListItemSongs)
-			} else {
-				cmd = m.SelectedPlayListItems.SetItems(playListItemSongs)
-			}
-			if msg.PaginationInfo != nil {
-				m.PaginationInfo = msg.PaginationInfo
-			} else {
-				m.PaginationInfo = nil
-			}
-			cmds = append(cmds, cmd)
+		m.LyricsView.Width = dims.mainWidth - 2
+		// the search bar, the breadcrumb and the border share the pane with the lyrics
+		m.LyricsView.Height = max(dims.contentHeight-6, 1)
+		if m.MainViewMode == LyricsMode {
+			m.setLyricsContent()
 		}
-		if msg.Err != nil {
-			alertCmd := m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
-			cmds = append(cmds, alertCmd)
+		if m.ShowHelp {
+			return m.openHelp()
 		}
+		return m, nil
+	case types.UpdatePlaylistMsg:
+		return m.handleUpdatePlaylistMsg(msg)
 	case tea.KeyMsg:
-		model, cmd := m.handleKeyPress(msg)
-		m = model
+		if m.ShowHelp {
+			// the keys scroll the help instead of the pane under it
+			return m.handleHelpKey(msg)
+		}
+		model, cmd, bound := m.handleKeyPress(msg)
+		m = model.closeBlurredOverlays()
+		if bound {
+			// the lists page with some of the bound keys, e.g. "u" or "l", so the key is not passed on to them
+			return m, cmd
+		}
 		cmds = append(cmds, cmd)
 	case tea.MouseMsg:
-		x := msg.X
-		y := msg.Y
-		if x > m.LibraryWidth && x <= (m.LibraryWidth+m.MainViewWidth) && y <= (m.Height-m.PlayerSectionHeight) {
-			if m.MainViewMode == LyricsMode && m.FocusedOn != SearchBar {
-				lyricsModel, cmd := m.LyricsView.Update(msg)
-				m.LyricsView = lyricsModel
-				cmds = append(cmds, cmd)
-			}
-		}
+		model, cmd := m.handleMouse(msg)
+		return model.closeBlurredOverlays(), cmd
 
 	default:
 	}
@@ -293,7 +267,7 @@ func (m Model) handleDbusMessage(msg types.MessageType, cmds []tea.Cmd) (Model,
 		cmds = append(cmds, cmd)
 		return m, tea.Batch(cmds...)
 	case types.PreviousTrack:
-		model, cmd := m.handleMusicChange(false, true)
+		model, cmd := m.playPreviousFromHistory()
 		m = model
 		cmds = append(cmds, cmd)
 		return m, tea.Batch(cmds...)
@@ -314,50 +288,183 @@ func (m Model) handlePagination(listModel *list.Model, ShouldAppendQueue bool, c
 		index := listModel.GlobalIndex()
 		currentIndex = &index
 	}
+	paginationInfo := m.PaginationInfo
+	if ShouldAppendQueue {
+		paginationInfo = nil
+		if m.MusicQueueList != nil {
+			paginationInfo = m.MusicQueueList.PaginationInfo
+		}
+	}
 	totalItems := listModel.Items()
-	if *currentIndex+5 >= len(totalItems) && m.PaginationInfo != nil && m.PaginationInfo.Next != "" {
+	if *currentIndex+5 >= len(totalItems) && paginationInfo != nil && paginationInfo.Next != "" {
 		if m.IsOnPagination {
 			return m, nil
 		}
 		m.IsOnPagination = true
-		var paginationInfo *types.PaginationInfo
-		if m.FocusedOn == QueueList && m.MusicQueueList != nil && m.MusicQueueList.PaginationInfo != nil {
-			paginationInfo = m.MusicQueueList.PaginationInfo
-		} else {
-			paginationInfo = m.PaginationInfo
-		}
 		return m, getNextPageItems(&m, paginationInfo, ShouldAppendQueue)
 	}
 	return m, nil
 }
 
-func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
+func (m Model) handleUpdatePlaylistMsg(msg types.UpdatePlaylistMsg) (Model, tea.Cmd) {
+	if msg.ShouldAppend || msg.Err != nil {
+		m.IsOnPagination = false
+	}
+	if msg.Err != nil {
+		m.IsSearchLoading = false
+		return m, m.Alert.NewAlertCmd(bubbleup.ErrorKey, msg.Err.Error())
+	}
+	if msg.Playlist == nil {
+		return m, nil
+	}
+
+	var playListItemSongs []list.Item
+	for _, item := range msg.Playlist {
+		if msg.ShouldAppendQueue {
+			item.IsItFromQueue = true
+		}
+		playListItemSongs = append(playListItemSongs, *item)
+	}
+	playListItemSongs = m.visibleItems(playListItemSongs)
+
+	if msg.ShouldAppendQueue {
+		if m.MusicQueueList == nil || m.MusicQueueList.PaginationInfo == nil || m.MusicQueueList.PaginationInfo.Next != msg.Continuation {
+			return m, nil
+		}
+		m.MusicQueueList.PaginationInfo = msg.PaginationInfo
+		return m, m.MusicQueueList.SetItems(append(m.MusicQueueList.Items(), playListItemSongs...))
+	}
+
+	if msg.ShouldAppend {
+		if m.PaginationInfo == nil || m.PaginationInfo.Next != msg.Continuation {
+			return m, nil
+		}
+		playListItemSongs = append(m.SelectedPlayListItems.Items(), playListItemSongs...)
+		m.loadedMorePages = true
+	} else {
+		m.loadedMorePages = false
+		m.MainViewMode = NormalMode
+		m.IsSearchLoading = false
+		m.Playlist = PlaylistPage{ID: msg.PlaylistID, Title: msg.PlaylistTitle, Owned: msg.PlaylistOwned}
+	}
+	m.PaginationInfo = msg.PaginationInfo
+	return m, m.SelectedPlayListItems.SetItems(playListItemSongs)
+}
+
+// handleKeyPress handles a key before the focused component gets it, the bool is set when the key ran an action
+// of the keymap and the component must not get it
+func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.FocusedOn == PlaylistPickerView {
+		model, cmd := m.handlePlaylistPickerKey(msg)
+		return model, cmd, false
+	}
+	if m.FocusedOn == PromptInput {
+		model, cmd := m.handlePromptKey(msg)
+		return model, cmd, false
+	}
+	if m.isFilterKey(msg.String()) {
+		return m, nil, false
+	}
+	if m.FocusedOn == SearchBar {
+		if model, cmd, ok := m.handleSearchBarKey(msg.String()); ok {
+			return model, cmd, false
+		}
+		// typed characters belong to the search input, not to the bindings
+		if keymap.IsText(msg.String()) {
+			return m, nil, false
+		}
+	}
 	switch msg.String() {
 	case "down", "j":
+		if m.FocusedOn == SearchResult {
+			model, cmd := m.loadMoreSearchResults()
+			return model, cmd, false
+		}
 		if m.FocusedOn != MainView && m.FocusedOn != QueueList {
-			return m, nil
+			return m, nil, false
 		}
 		if m.MainViewMode == HomePageMode {
 			var cmd tea.Cmd
 			m.HomePageList, cmd = m.HomePageList.Update(msg)
-			return m, cmd
+			return m, cmd, false
 		}
 		listModel := getListItemForMusicToChoose(&m, m.FocusedOn)
-		return m.handlePagination(listModel, m.FocusedOn == QueueList, nil)
+		model, cmd := m.handlePagination(listModel, m.FocusedOn == QueueList, nil)
+		return model, cmd, false
 	case "up", "k":
 		if m.FocusedOn != MainView && m.FocusedOn != QueueList {
-			return m, nil
+			return m, nil, false
 		}
 		if m.MainViewMode == HomePageMode {
 			var cmd tea.Cmd
 			m.HomePageList, cmd = m.HomePageList.Update(msg)
-			return m, cmd
+			return m, cmd, false
 		}
-		return m, nil
-	case "ctrl+k":
+		return m, nil, false
+	}
+	action, ok := m.Keys.Lookup(msg.String(), m.keyPanes()...)
+	if !ok {
+		return m, nil, false
+	}
+	model, cmd := m.dispatchAction(action)
+	return model, cmd, true
+}
+
+// dispatchAction runs the action the way the view of the main pane handles it, the way the focused pane does
+// when the view has no use for it
+func (m Model) dispatchAction(action keymap.Action) (Model, tea.Cmd) {
+	if m.FocusedOn == MainView {
+		switch m.MainViewMode {
+		case TrackInfoMode:
+			if model, cmd, ok := m.handleTrackInfoKey(action); ok {
+				return model, cmd
+			}
+		case BlocklistMode:
+			if model, cmd, ok := m.handleBlocklistKey(action); ok {
+				return model, cmd
+			}
+		case LyricsMode:
+			if model, cmd, ok := m.handleLyricsKey(action); ok {
+				return model, cmd
+			}
+		case AlbumMode:
+			if model, cmd, ok := m.handleAlbumKey(action); ok {
+				return model, cmd
+			}
+		case ArtistMode:
+			if model, cmd, ok := m.handleArtistKey(action); ok {
+				return model, cmd
+			}
+		case ExploreMode:
+			if model, cmd, ok := m.handleExploreKey(action); ok {
+				return model, cmd
+			}
+		}
+	}
+	return m.runAction(action)
+}
+
+// runAction does what the action is bound to in the focused pane
+func (m Model) runAction(action keymap.Action) (Model, tea.Cmd) {
+	switch action {
+	case keymap.Help:
+		return m.openHelp()
+	case keymap.CommandPalette:
+		return m.openCommandPalette()
+	case keymap.NextTab:
+		return m.switchSearchTab(1)
+	case keymap.PreviousTab:
+		return m.switchSearchTab(-1)
+	case keymap.Search:
 		m.FocusedOn = SearchBar
 		return m, m.Search.Focus()
-	case "escape":
+	case keymap.Back:
+		if overlayOpen(&m) {
+			// moving the focus off the overlay closes it
+			m.FocusedOn = MainView
+			updateDelegate(&m)
+			return m, nil
+		}
 		if m.MainViewMode == HomePageMode && m.HomePageViewMode == HomePageContentView {
 			var items []list.Item
 			for i, section := range m.HomePageData.Sections {
@@ -372,66 +479,57 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.HomePageViewMode = HomePageSectionView
 			return m, nil
 		}
-	case "a":
+	case keymap.AddToQueue:
 		return m.addMusicToQueue()
-	case "r":
-		if m.FocusedOn == QueueList && m.MusicQueueList != nil {
+	case keymap.ShowTrackInfo:
+		return m.openTrackInfo()
+	case keymap.BlockArtist:
+		return m.blockHighlightedArtist()
+	case keymap.BlockTrack:
+		return m.blockHighlightedTrack()
+	case keymap.RemoveFromQueue:
+		if m.MusicQueueList != nil {
 			if len(m.MusicQueueList.Model.Items()) > 0 {
-				m.MusicQueueList.Model.RemoveItem(m.MusicQueueList.GlobalIndex())
+				m.recordQueueChange("remove track")
+				return m, listfilter.RemoveGlobal(&m.MusicQueueList.Model, m.MusicQueueList.GlobalIndex())
 			}
 		}
-	case "ctrl+l":
+	case keymap.AddToPlaylist, keymap.RemoveFromPlaylist, keymap.NewPlaylist, keymap.RenamePlaylist, keymap.DeletePlaylist:
+		return m.handlePlaylistKey(action)
+	case keymap.RemoveDuplicates:
+		return m.removeQueueDuplicates()
+	case keymap.Expand, keymap.Collapse:
+		return m.collapseOrExpandLibrary(action == keymap.Expand)
+	case keymap.Undo:
+		return m.undoQueueChange()
+	case keymap.Redo:
+		return m.redoQueueChange()
+	case keymap.SaveQueue:
+		return m.saveQueueAsPlaylist()
+	case keymap.ExportPlaylist:
+		return m.openExportPrompt()
+	case keymap.ImportPlaylist:
+		return m.openImportPrompt()
+	case keymap.ToggleLyrics:
 		if m.MainViewMode == LyricsMode {
-			m.MainViewMode = NormalMode
-			return m, nil
-		}
-		return m.getMusicLyrics(m.SelectedTrack)
-	case "l":
-		if m.SelectedTrack != nil && m.SelectedTrack.Track != nil {
-			cmd := func() tea.Msg {
-				var shouldRemove bool
-				if m.SelectedTrack.isLiked {
-					shouldRemove = true
-				} else {
-					shouldRemove = false
-				}
-				ctx, _ := context.WithCancel(context.Background())
-				_, err := m.YtMusicClient.SaveRemoveTrack(ctx, &musicpb.SaveRemoveTrackRequest{
-					VideoIds: []string{},
-					IsRemove: true,
-				})
-
-				if err != nil {
-					slog.Error(err.Error())
-				}
-				likeUnlikeTrackMsg := types.LikeUnlikeTrackMsg{
-					TrackID: m.SelectedTrack.Track.Track.ID,
-					Like:    !shouldRemove,
-					Err:     err,
-				}
-				return likeUnlikeTrackMsg
-			}
-			return m, cmd
-		}
-	case " ":
-		if m.FocusedOn != Player {
-			return m, nil
-		}
+			return m.closeLyrics(), nil
+		}
+		return m.openLyrics()
+	case keymap.LikePlaying:
+		return m.ratePlayingTrack(types.RatingLike)
+	case keymap.LikeHighlighted:
+		return m.rateHighlightedTrack(types.RatingLike)
+	case keymap.DislikePlaying:
+		return m.ratePlayingTrack(types.RatingDislike)
+	case keymap.DislikeHighlighted:
+		return m.rateHighlightedTrack(types.RatingDislike)
+	case keymap.PlayPause:
 		return m.HandleMusicPausePlay()
-	case "b":
-		if m.FocusedOn != Player {
-			return m, nil
-		}
-		return m.handleMusicChange(false, true)
-	case "n":
-		if m.FocusedOn != Player {
-			return m, nil
-		}
+	case keymap.PreviousTrack:
+		return m.playPreviousFromHistory()
+	case keymap.NextTrack:
 		return m.handleMusicChange(true, true)
-	case "q", "ctrl+c":
-		if m.FocusedOn == SearchBar {
-			return m, nil
-		}
+	case keymap.Quit:
 		_ = m.BackendProcess.Process.Signal(syscall.SIGTERM)
 		if m.playbackCancel != nil {
 			m.playbackCancel()
@@ -445,12 +543,23 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.PlayerProcess = nil
 		}
 		return m, tea.Quit
-	case "tab":
+	case keymap.NextPane:
 		return changeFocusMode(&m, false)
-	case "shift+tab":
+	case keymap.PreviousPane:
 		return changeFocusMode(&m, true)
-	case "enter":
-		return m.handleEnterKey()
+	case keymap.ToggleSidebar:
+		return m.togglePane(SideView), nil
+	case keymap.ToggleQueue:
+		return m.togglePane(QueueList), nil
+	case keymap.Select:
+		fromOverlay := m.FocusedOn == SideView && overlayOpen(&m) && !foldsLibrary(m.SideBarList.SelectedItem())
+		model, cmd := m.handleEnterKey()
+		if fromOverlay && model.FocusedOn == SideView {
+			// the overlay would cover what was opened
+			model.FocusedOn = MainView
+			updateDelegate(&model)
+		}
+		return model, cmd
 	}
 	return m, nil
 }
@@ -462,13 +571,15 @@ func getNextPageItems(m *Model, paginationInfo *types.PaginationInfo, ShouldAppe
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			playlistItems, err := m.YtMusicClient.GetPlaylistItems(ctx, &musicpb.GetPlaylistItemsRequest{
-				PlaylistId: paginationInfo.NextItemID,
-				Limit:      100,
+				PlaylistId:   paginationInfo.NextItemID,
+				Limit:        100,
+				Continuation: paginationInfo.Next,
 			})
 			if err != nil {
 				return types.UpdatePlaylistMsg{
-					Playlist: nil,
-					Err:      err,
+					Playlist:          nil,
+					Err:               err,
+					ShouldAppendQueue: ShouldAppendQueue,
 				}
 			}
 			var tracks []*types.PlaylistTrackObject
@@ -481,8 +592,9 @@ func getNextPageItems(m *Model, paginationInfo *types.PaginationInfo, ShouldAppe
 				Playlist:          tracks,
 				Err:               nil,
 				ShouldAppend:      true,
-				PaginationInfo:    paginationInfo,
+				PaginationInfo:    types.NewPaginationInfo(types.NextPageURLTypePlaylistTracks, paginationInfo.NextItemID, playlistItems.Continuation),
 				ShouldAppendQueue: ShouldAppendQueue,
+				Continuation:      paginationInfo.Next,
 			}
 		}
 	case types.NextPageURLTypeUserSavedItems:
@@ -490,12 +602,14 @@ func getNextPageItems(m *Model, paginationInfo *types.PaginationInfo, ShouldAppe
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			userSavedTracks, err := m.YtMusicClient.GetUserSavedTracks(ctx, &musicpb.GetUserSavedTracksRequest{
-				Limit: 100,
+				Limit:        100,
+				Continuation: paginationInfo.Next,
 			})
 			if err != nil {
 				return types.UpdatePlaylistMsg{
-					Playlist: nil,
-					Err:      err,
+					Playlist:          nil,
+					Err:               err,
+					ShouldAppendQueue: ShouldAppendQueue,
 				}
 			}
 			var playlistItems []*types.PlaylistTrackObject
@@ -508,18 +622,14 @@ func getNextPageItems(m *Model, paginationInfo *types.PaginationInfo, ShouldAppe
 				Playlist:          playlistItems,
 				Err:               nil,
 				ShouldAppend:      true,
-				PaginationInfo:    paginationInfo,
+				PaginationInfo:    types.NewPaginationInfo(types.NextPageURLTypeUserSavedItems, "", userSavedTracks.Continuation),
 				ShouldAppendQueue: ShouldAppendQueue,
+				Continuation:      paginationInfo.Next,
 			}
 		}
 	}
 	return nil
 }
-func (m Model) getMusicLyrics(track *SelectedTrack) (Model, tea.Cmd) {
-	//TODO: implement lyrics later
-	return m, nil
-}
-
 func (m Model) handleMusicChange(isForward, shouldRemoveTheCacheFile bool) (Model, tea.Cmd) {
 	if m.MusicQueueList == nil {
 		return m, nil
@@ -537,7 +647,7 @@ func (m Model) handleMusicChange(isForward, shouldRemoveTheCacheFile bool) (Mode
 	}
 	if len(validItems) != len(m.MusicQueueList.Model.Items()) {
 		cmd := m.MusicQueueList.Model.SetItems(validItems)
-		m.MusicQueueList.Model.Select(0)
+		listfilter.SelectGlobal(&m.MusicQueueList.Model, 0)
 		return m, cmd
 	}
 
@@ -568,23 +678,36 @@ func (m Model) handleMusicChange(isForward, shouldRemoveTheCacheFile bool) (Mode
 
 	var musicToPlay types.PlaylistTrackObject
 	var found bool
-	for i := 0; i < len(m.MusicQueueList.Model.Items()); i++ {
-		idx := (nextTrackIndex + i) % len(m.MusicQueueList.Model.Items())
+	step := 1
+	if !isForward {
+		step = -1
+	}
+	count := len(m.MusicQueueList.Model.Items())
+	for i := 0; i < count; i++ {
+		idx := ((nextTrackIndex+i*step)%count + count) % count
 		item := m.MusicQueueList.Model.Items()[idx]
 		playlistTrack, ok := item.(types.PlaylistTrackObject)
-		if ok {
-			musicToPlay = playlistTrack
-			nextTrackIndex = idx
-			found = true
-			break
+		if !ok {
+			continue
+		}
+		if !m.Filter.Plays(playlistTrack.Track) {
+			if config.GetConfig().SkipOnNoMatch {
+				continue
+			}
+			listfilter.SelectGlobal(&m.MusicQueueList.Model, idx)
+			return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, "stopped at "+playlistTrack.Track.Name+", it does not pass the content filter")
 		}
+		musicToPlay = playlistTrack
+		nextTrackIndex = idx
+		found = true
+		break
 	}
 
 	if !found {
 		slog.Error("no valid PlaylistTrackObject found in music queue")
 		return m, nil
 	}
-	m.MusicQueueList.Model.Select(nextTrackIndex)
+	listfilter.SelectGlobal(&m.MusicQueueList.Model, nextTrackIndex)
 	var paginationCmd tea.Cmd
 	var model Model
 	if isForward {
@@ -598,11 +721,49 @@ func (m Model) handleMusicChange(isForward, shouldRemoveTheCacheFile bool) (Mode
 	return m, tea.Batch(cmd, paginationCmd)
 }
 
+// playPreviousFromHistory steps back through the tracks that were actually played,
+// it falls back to the previous queue item when there is no history to go back to
+func (m Model) playPreviousFromHistory() (Model, tea.Cmd) {
+	entries := m.History.Entries()
+	cursor := m.historyCursor + 1
+	if m.historyCursor == 0 && m.SelectedTrack != nil && m.SelectedTrack.Track != nil && m.SelectedTrack.isRecorded {
+		// the current track is already the newest entry so skip over it
+		if len(entries) > 0 && entries[len(entries)-1].Track.ID == m.SelectedTrack.Track.Track.ID {
+			cursor++
+		}
+	}
+	index := len(entries) - cursor
+	if index < 0 {
+		if len(entries) == 0 {
+			return m.handleMusicChange(false, true)
+		}
+		return m, nil
+	}
+	model, cmd := m.PlaySelectedMusic(types.PlaylistTrackObject{
+		Track: entries[index].Track,
+	})
+	m = model
+	m.historyCursor = cursor
+	// replaying an old entry should not push it on top of the history again
+	m.SelectedTrack.isRecorded = true
+	return m, cmd
+}
+
 func (m Model) addMusicToQueue() (Model, tea.Cmd) {
 	var itemToAdd list.Item
 	var currentlyPlayingTrackID string
-	if m.FocusedOn == MainView && m.MainViewMode == NormalMode {
+	if m.FocusedOn == MainView && m.MainViewMode.showsTrackList() {
 		itemToAdd = m.SelectedPlayListItems.SelectedItem()
+	} else if m.FocusedOn == MainView && m.MainViewMode == ArtistMode {
+		if row, ok := m.Artist.List.SelectedItem().(types.ArtistPageItem); ok && row.Track != nil {
+			itemToAdd = types.PlaylistTrackObject{Track: *row.Track}
+		}
+	} else if m.FocusedOn == MainView && m.MainViewMode == ExploreMode {
+		if row, ok := m.Explore.List.SelectedItem().(types.ExploreItem); ok && row.Track != nil {
+			itemToAdd = types.PlaylistTrackObject{Track: *row.Track}
+		}
+	} else if m.FocusedOn == MainView && m.MainViewMode == TrackInfoMode {
+		itemToAdd = types.PlaylistTrackObject{Track: m.TrackInfo.Track}
 	} else if m.FocusedOn == SearchResult && m.MainViewMode == SearchResultMode {
 		if len(m.SearchResult.Items()) > 0 {
 			if track, ok := m.SearchResult.SelectedItem().(types.Track); ok {
@@ -621,7 +782,12 @@ func (m Model) addMusicToQueue() (Model, tea.Cmd) {
 		currentlyPlayingTrackID = m.SelectedTrack.Track.Track.ID
 	}
 
+	if track := itemToAdd.(types.PlaylistTrackObject).Track; shouldPreventDuplicates() && m.isInQueue(track) {
+		return m, m.Alert.NewAlertCmd(bubbleup.WarnKey, track.Name+" is already in the queue")
+	}
+
 	var musicQueue = m.MusicQueueList.Items()
+	m.recordQueueChange("add track")
 
 	if len(musicQueue) == 0 {
 		var validItems []list.Item
@@ -708,7 +874,7 @@ func getListItemForMusicToChoose(m *Model, focusedOn FocusedOn) *list.Model {
 			return &m.HomePageList
 		}
 	}
-	if focusedOn == MainView && m.MainViewMode == NormalMode {
+	if focusedOn == MainView && m.MainViewMode.showsTrackList() {
 		return &m.SelectedPlayListItems
 	}
 	if focusedOn == QueueList && m.MusicQueueList != nil {
@@ -719,30 +885,34 @@ func getListItemForMusicToChoose(m *Model, focusedOn FocusedOn) *list.Model {
 
 func (m Model) handleEnterKey() (Model, tea.Cmd) {
 	if m.FocusedOn == SideView {
+		if model, cmd, ok := m.handleLibraryEnter(); ok {
+			return model, cmd
+		}
 		if item, ok := m.SideBarList.SelectedItem().(types.SidebarItem); ok {
 			newBreadcrumbItems := []types.Breadcrumb{{Name: item.Name, Icon: item.Icon}}
 			m.BreadcrumbItems = newBreadcrumbItems
 			if strings.ToLower(strings.Trim(item.Name, " ")) == "home" {
-				homePageFeed := func() tea.Msg {
-					ctx, cancel := context.WithCancel(context.Background())
-					defer cancel()
-					homePage, err := m.YtMusicClient.GetHomePage(ctx, &musicpb.GetHomePageRequest{})
-					if err != nil {
-						slog.Error(err.Error())
-						return types.HomePageResponseMsg{
-							Response: homePage,
-							Err:      err,
-						}
-					}
-					return types.HomePageResponseMsg{
-						Response: homePage,
-						Err:      nil,
-					}
-				}
-				return m, tea.Batch(SendLoadingCmd(), homePageFeed)
+				return m, tea.Batch(SendLoadingCmd(), m.getHomePage())
+			}
+			if strings.ToLower(strings.Trim(item.Name, " ")) == "explore" {
+				return m.openExplore()
+			}
+			if isBlocklistSidebarItem(item) {
+				return m.openBlocklist()
+			}
+			if strings.ToLower(strings.Trim(item.Name, " ")) == "recently played" {
+				m.FocusedOn = MainView
+				updateDelegate(&m)
+				return m, m.getRecentlyPlayedTracks()
 			}
 		}
 	}
+	if m.FocusedOn == MainView && m.MainViewMode == ArtistMode {
+		return m.handleArtistEnter()
+	}
+	if m.FocusedOn == MainView && m.MainViewMode == ExploreMode {
+		return m.handleExploreEnter()
+	}
 	if m.FocusedOn == MainView || m.FocusedOn == QueueList {
 		if m.MainViewMode == HomePageMode && m.HomePageViewMode == HomePageSectionView {
 			listItemToChooseMusicFrom := getListItemForMusicToChoose(&m, m.FocusedOn)
@@ -768,20 +938,9 @@ func (m Model) handleEnterKey() (Model, tea.Cmd) {
 			if !ok {
 				return m, nil
 			}
-			playlistDetailMsg := func() tea.Msg {
-				ctx, cancel := context.WithCancel(context.Background())
-				defer cancel()
-				playlistItems, err := m.YtMusicClient.GetPlaylistItems(ctx, &musicpb.GetPlaylistItemsRequest{
-					PlaylistId: item.PlaylistID,
-				})
-				return types.PlaylistDetailMsg{
-					Playlist: playlistItems,
-					Err:      err,
-				}
-			}
 			newBreadcrumbItems := []types.Breadcrumb{{Name: item.ItemTitle, Icon: ""}}
 			m.BreadcrumbItems = append(m.BreadcrumbItems, newBreadcrumbItems...)
-			return m, tea.Batch(SendLoadingCmd(), playlistDetailMsg)
+			return m, tea.Batch(SendLoadingCmd(), m.getPlaylistItems(item.PlaylistID))
 		}
 
 		listItemToChooseMusicFrom := getListItemForMusicToChoose(&m, m.FocusedOn)
@@ -809,8 +968,22 @@ func (m Model) handleEnterKey() (Model, tea.Cmd) {
 		if m.MusicQueueList == nil {
 			return m, nil
 		}
+		index := listItemToChooseMusicFrom.GlobalIndex()
+		if shouldPreventDuplicates() {
+			items = uniqueTrackItems(items, selectedMusic.Track.ID)
+			index = slices.IndexFunc(items, func(item list.Item) bool {
+				track, ok := item.(types.PlaylistTrackObject)
+				return ok && track.Track.ID == selectedMusic.Track.ID
+			})
+		}
+		m.recordQueueChange("replace queue")
+		m.MusicQueueList.Model.ResetFilter()
 		m.MusicQueueList.Model.SetItems(items)
-		m.MusicQueueList.Model.Select(m.MusicQueueList.GlobalIndex())
+		m.MusicQueueList.Model.Select(max(index, 0))
+		if m.FocusedOn == MainView {
+			// the queue keeps loading the rest of the list as playback gets close to its end
+			m.MusicQueueList.PaginationInfo = m.PaginationInfo
+		}
 		return m.PlaySelectedMusic(selectedMusic)
 	}
 
@@ -821,69 +994,7 @@ func (m Model) handleEnterKey() (Model, tea.Cmd) {
 			return m, nil
 		}
 
-		loadingCmd := SendLoadingCmd()
-		searchingCmd := func() tea.Msg {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			searchResults, err := m.YtMusicClient.GetSearchResults(ctx, &musicpb.GetSearchResultsRequest{
-				Query: query,
-			})
-
-			if err != nil {
-				slog.Error(err.Error())
-				return types.SpotifySearchResultMsg{
-					Result: nil,
-					Err:    err,
-				}
-			}
-
-			var items []list.Item
-			for _, s := range searchResults.Songs {
-				track := types.MapSearchResultSongToTrack(s)
-				items = append(items, track)
-			}
-			for _, a := range searchResults.Artists {
-				artist := types.Artist{
-					ID:     a.BrowseId,
-					Name:   a.Name,
-					Images: types.MapThumbnailsToImages(a.Thumbnails),
-				}
-				items = append(items, artist)
-			}
-			for _, p := range searchResults.Playlists {
-				playlist := types.Playlist{
-					ID:          p.BrowseId,
-					Name:        p.Title,
-					Description: p.ItemCount,
-					Images:      types.MapThumbnailsToImages(p.Thumbnails),
-					Author:      p.Author,
-				}
-				items = append(items, playlist)
-			}
-			for _, al := range searchResults.Albums {
-				album := types.Album{
-					ID:      al.BrowseId,
-					Name:    al.Title,
-					Artists: types.MapArtistsToArtists(al.Artists),
-					Images:  types.MapThumbnailsToImages(al.Thumbnails),
-					Year:    al.Year,
-					Type:    al.Type,
-				}
-				items = append(items, album)
-			}
-
-			searchResult := &types.SearchResponse{
-				Items: items,
-			}
-
-			return types.SpotifySearchResultMsg{
-				Result: searchResult,
-				Err:    nil,
-			}
-		}
-		m.SearchQuery = query
-		return m, tea.Batch(loadingCmd, searchingCmd)
+		return m.startSearch(query)
 	}
 
 	if m.FocusedOn == SideView {
@@ -899,8 +1010,7 @@ func (m Model) handleEnterKey() (Model, tea.Cmd) {
 			cmd := m.getPlaylistItems(selectedItem.ID)
 			return m, tea.Batch(loadingCmd, cmd)
 		case types.Artist:
-			cmd := m.getArtistTracks(selectedItem.ID)
-			return m, tea.Batch(loadingCmd, cmd)
+			return m.openArtist(selectedItem.ID, selectedItem.Name)
 		case types.UserSavedTracksListItem:
 			cmd := m.getUserSavedTracks()
 			return m, tea.Batch(loadingCmd, cmd)
@@ -976,40 +1086,34 @@ func (m Model) handleEnterKey() (Model, tea.Cmd) {
 					slog.Error("failed to cast the selected item to types.Artist")
 					return m, nil
 				}
-				loadingCmd := SendLoadingCmd()
-				cmd := m.getArtistTracks(artist.ID)
-				m.MainViewMode = NormalMode
-				m.FocusedOn = MainView
-				updateDelegate(&m)
-				return m, tea.Batch(cmd, loadingCmd)
+				return m.openArtist(artist.ID, artist.Name)
+			case types.SearchResultAlbum:
+				album, ok := selectedItem.(types.Album)
+				if !ok {
+					slog.Error("failed to cast the selected item to types.Album")
+					return m, nil
+				}
+				return m.openAlbum(album.ID, album.Name)
 			}
 		}
 	}
 	return m, nil
 }
 
-func (m Model) getArtistTracks(artistID string) tea.Cmd {
+func (m Model) getHomePage() tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
-		artistSongs, err := m.YtMusicClient.GetArtistTopTracks(ctx, &musicpb.GetArtistTopTracksRequest{
-			ChannelId: artistID,
-		})
+		homePage, err := m.YtMusicClient.GetHomePage(ctx, &musicpb.GetHomePageRequest{})
 		if err != nil {
 			slog.Error(err.Error())
-			return types.UpdatePlaylistMsg{
-				Playlist: nil,
+			return types.HomePageResponseMsg{
+				Response: nil,
 				Err:      err,
 			}
 		}
-		var tracks []*types.PlaylistTrackObject
-		for _, track := range artistSongs.Tracks {
-			tracks = append(tracks, &types.PlaylistTrackObject{
-				Track: types.MapSongToTrack(track),
-			})
-		}
-		return types.UpdatePlaylistMsg{
-			Playlist: tracks,
+		return types.HomePageResponseMsg{
+			Response: homePage,
 			Err:      nil,
 		}
 	}
@@ -1035,6 +1139,23 @@ func (m Model) getUserSavedTracks() tea.Cmd {
 				Track: types.MapSongToTrack(track),
 			})
 		}
+		return types.UpdatePlaylistMsg{
+			Playlist:       tracks,
+			Err:            nil,
+			ShouldAppend:   false,
+			PaginationInfo: types.NewPaginationInfo(types.NextPageURLTypeUserSavedItems, "", savedTracks.Continuation),
+		}
+	}
+}
+
+func (m Model) getRecentlyPlayedTracks() tea.Cmd {
+	return func() tea.Msg {
+		tracks := []*types.PlaylistTrackObject{}
+		for _, entry := range m.History.Recent(0) {
+			tracks = append(tracks, &types.PlaylistTrackObject{
+				Track: entry.Track,
+			})
+		}
 		return types.UpdatePlaylistMsg{
 			Playlist:     tracks,
 			Err:          nil,
@@ -1065,9 +1186,13 @@ func (m Model) getPlaylistItems(playlistID string) tea.Cmd {
 			})
 		}
 		return types.UpdatePlaylistMsg{
-			Playlist:     tracks,
-			Err:          nil,
-			ShouldAppend: false,
+			Playlist:       tracks,
+			Err:            nil,
+			ShouldAppend:   false,
+			PaginationInfo: types.NewPaginationInfo(types.NextPageURLTypePlaylistTracks, playlistID, playlistItems.Continuation),
+			PlaylistID:     playlistID,
+			PlaylistTitle:  playlistItems.Title,
+			PlaylistOwned:  playlistItems.Owned,
 		}
 	}
 }
@@ -1078,31 +1203,9 @@ func (m Model) PlaySelectedMusic(selectedMusic types.PlaylistTrackObject) (Model
 	for _, artist := range selectedMusic.Track.Artists {
 		artistNames = append(artistNames, artist.Name)
 	}
-	if m.playbackCancel != nil {
-		m.playbackCancel()
-		m.playbackCancel = nil
-	}
-	if m.PlayerProcess != nil {
-		err := m.PlayerProcess.Close()
-		if err != nil {
-			slog.Error(err.Error())
-		}
-		m.PlayerProcess = nil
-	}
-
-	playCtx, cancel := context.WithCancel(context.Background())
-	m.playbackCancel = cancel
-
-	cmd := youtube.SearchAndDownloadMusic(playCtx, selectedMusic.Track.ID, m.CoreDepsPath, func() (string, error) {
-		getStreamURLResponse, err := m.YtMusicClient.GetVideoStreamURL(context.Background(), &musicpb.GetVideoStreamURLRequest{
-			VideoId: selectedMusic.Track.ID,
-		})
-		if err != nil {
-			return "", err
-		}
-		return getStreamURLResponse.Url, nil
-	})
-
+	m.historyCursor = 0
+	model, cmd := m.startPlayback(selectedMusic.Track.ID, 0)
+	m = model
 	cmds = append(cmds, cmd)
 	metadata := getMusicMetadata(MusicMetadata{
 		artistName: strings.Join(artistNames, ","),
@@ -1131,9 +1234,10 @@ func (m Model) PlaySelectedMusic(selectedMusic types.PlaylistTrackObject) (Model
 		}
 	}
 	m.SelectedTrack = &SelectedTrack{
-		isLiked: false,
-		Track:   &selectedMusic,
+		rating: m.TrackRating(selectedMusic.Track),
+		Track:  &selectedMusic,
 	}
+	cmds = append(cmds, m.Artwork.load(nowPlayingArtworkURL(&m), nowPlayingArtworkCols, nowPlayingArtworkRows))
 
 	if m.MainViewMode == LyricsMode {
 		model, cmd := m.getMusicLyrics(m.SelectedTrack)
@@ -1143,9 +1247,90 @@ func (m Model) PlaySelectedMusic(selectedMusic types.PlaylistTrackObject) (Model
 	return m, tea.Batch(cmds...)
 }
 
+// startPlayback stops what is playing and streams the track from start
+func (m Model) startPlayback(videoID string, start time.Duration) (Model, tea.Cmd) {
+	if m.playbackCancel != nil {
+		m.playbackCancel()
+		m.playbackCancel = nil
+	}
+	if m.PlayerProcess != nil {
+		err := m.PlayerProcess.Close()
+		if err != nil {
+			slog.Error(err.Error())
+		}
+		m.PlayerProcess = nil
+	}
+
+	playCtx, cancel := context.WithCancel(context.Background())
+	m.playbackCancel = cancel
+	return m, youtube.SearchAndDownloadMusic(playCtx, videoID, start, float64(m.Volume)/100, m.CoreDepsPath, func() (string, error) {
+		getStreamURLResponse, err := m.YtMusicClient.GetVideoStreamURL(context.Background(), &musicpb.GetVideoStreamURLRequest{
+			VideoId: videoID,
+		})
+		if err != nil {
+			return "", err
+		}
+		return getStreamURLResponse.Url, nil
+	})
+}
+
+// Seek plays the current track from position, the stream is opened again at that point
+func (m Model) Seek(position time.Duration) (Model, tea.Cmd) {
+	if m.SelectedTrack == nil || m.SelectedTrack.Track == nil {
+		return m, nil
+	}
+	total := time.Duration(m.SelectedTrack.Track.Track.DurationMS) * time.Millisecond
+	// the last second is left so seeking to the end does not skip the track straight away
+	position = max(min(position, total-time.Second), 0)
+	m.SelectedTrack.heardBeforeSeek = m.SelectedTrack.heard(m.PlayedSeconds)
+	m.SelectedTrack.playingFrom = position.Seconds()
+	m.PlayedSeconds = position.Seconds()
+	if m.MainViewMode == LyricsMode {
+		m.followLyrics()
+	}
+	return m.startPlayback(m.SelectedTrack.Track.Track.ID, position)
+}
+
 func changeFocusMode(m *Model, shift bool) (Model, tea.Cmd) {
-	var next, prev FocusedOn
 	switch m.FocusedOn {
+	case SideView, MainView, SearchResult, QueueList, Player:
+	default:
+		if shift {
+			items := m.SelectedPlayListItems.Items()
+			if len(items) > 0 {
+				m.FocusedOn = MainView
+				m.SelectedPlayListItems.Select(len(items) - 1)
+			} else {
+				m.FocusedOn = SideView
+			}
+		} else {
+			m.FocusedOn = SideView
+		}
+		if !paneShown(m, m.FocusedOn) {
+			m.FocusedOn = MainView
+		}
+		return *m, nil
+	}
+
+	// a folded pane is passed over, the main pane and the player are always shown
+	for {
+		next, prev := adjacentPanes(m, m.FocusedOn)
+		if shift {
+			m.FocusedOn = prev
+		} else {
+			m.FocusedOn = next
+		}
+		if paneShown(m, m.FocusedOn) {
+			break
+		}
+	}
+
+	updateDelegate(m)
+	return *m, nil
+}
+
+func adjacentPanes(m *Model, pane FocusedOn) (next, prev FocusedOn) {
+	switch pane {
 	case SideView:
 		next, prev = MainView, Player
 	case MainView:
@@ -1163,29 +1348,8 @@ func changeFocusMode(m *Model, shift bool) (Model, tea.Cmd) {
 		next = Player
 	case Player:
 		next, prev = SideView, QueueList
-	default:
-		if shift {
-			items := m.SelectedPlayListItems.Items()
-			if len(items) > 0 {
-				m.FocusedOn = MainView
-				m.SelectedPlayListItems.Select(len(items) - 1)
-			} else {
-				m.FocusedOn = SideView
-			}
-			return *m, nil
-		}
-		m.FocusedOn = SideView
-		return *m, nil
 	}
-
-	if shift {
-		m.FocusedOn = prev
-	} else {
-		m.FocusedOn = next
-	}
-
-	updateDelegate(m)
-	return *m, nil
+	return next, prev
 }
 
 func updateDelegate(m *Model) {
@@ -1199,6 +1363,10 @@ func updateDelegate(m *Model) {
 	m.SideBarList.SetDelegate(CustomDelegate{Model: m})
 	m.HomePageList.SetDelegate(CustomDelegate{Model: m})
 	m.SearchResult.SetDelegate(CustomDelegate{Model: m})
+	m.PlaylistPicker.List.SetDelegate(CustomDelegate{Model: m})
+	m.Artist.List.SetDelegate(CustomDelegate{Model: m})
+	m.Explore.List.SetDelegate(CustomDelegate{Model: m})
+	m.Blocklist.SetDelegate(CustomDelegate{Model: m})
 }
 
 func updateFocusedComponent(m *Model, msg tea.Msg, cmdsFromParent *[]tea.Cmd) (Model, tea.Cmd) {
@@ -1208,8 +1376,12 @@ func updateFocusedComponent(m *Model, msg tea.Msg, cmdsFromParent *[]tea.Cmd) (M
 	switch m.FocusedOn {
 	case SearchBar:
 		m.Search.Focus()
+		value := m.Search.Value()
 		m.Search, cmd = m.Search.Update(msg)
 		cmds = append(cmds, cmd)
+		if _, ok := msg.(tea.KeyMsg); ok && m.Search.Value() != value {
+			cmds = append(cmds, m.searchValueChanged())
+		}
 	case SideView:
 		m.Search.Blur()
 		m.SideBarList, cmd = m.SideBarList.Update(msg)
@@ -1222,13 +1394,35 @@ func updateFocusedComponent(m *Model, msg tea.Msg, cmdsFromParent *[]tea.Cmd) (M
 		cmds = append(cmds, cmd)
 	case MainView:
 		switch m.MainViewMode {
-		case NormalMode:
+		case NormalMode, AlbumMode:
 			m.SelectedPlayListItems, cmd = m.SelectedPlayListItems.Update(msg)
 			cmds = append(cmds, cmd)
+		case ArtistMode:
+			m.Artist.List, cmd = m.Artist.List.Update(msg)
+			cmds = append(cmds, cmd)
+		case ExploreMode:
+			m.Explore.List, cmd = m.Explore.List.Update(msg)
+			cmds = append(cmds, cmd)
+		case BlocklistMode:
+			m.Blocklist, cmd = m.Blocklist.Update(msg)
+			cmds = append(cmds, cmd)
+		case LyricsMode:
+			m.LyricsView, cmd = m.LyricsView.Update(msg)
+			cmds = append(cmds, cmd)
 		}
 	case SearchResult:
 		m.SearchResult, cmd = m.SearchResult.Update(msg)
 		cmds = append(cmds, cmd)
+	case PromptInput:
+		m.Prompt.Input, cmd = m.Prompt.Input.Update(msg)
+		cmds = append(cmds, cmd)
+	case PlaylistPickerView:
+		if m.PlaylistPicker.IsNaming {
+			m.PlaylistPicker.Input, cmd = m.PlaylistPicker.Input.Update(msg)
+		} else {
+			m.PlaylistPicker.List, cmd = m.PlaylistPicker.List.Update(msg)
+		}
+		cmds = append(cmds, cmd)
 	default:
 	}
 	return *m, tea.Batch(cmds...)
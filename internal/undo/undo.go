@@ -0,0 +1,71 @@
+package undo
+
+// DefaultDepth is how many changes are remembered before the oldest one is dropped
+const DefaultDepth = 50
+
+// Snapshot is the state before (or, on the redo side, after) a change together with a short description of the change
+type Snapshot[T any] struct {
+	Label string
+	State T
+}
+
+// Stack is a bounded undo/redo history of snapshots, the zero value is not usable, use New
+type Stack[T any] struct {
+	depth int
+	undo  []Snapshot[T]
+	redo  []Snapshot[T]
+}
+
+func New[T any](depth int) *Stack[T] {
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	return &Stack[T]{depth: depth}
+}
+
+// Record saves the state as it was before a change, recording a new change clears the redo history
+func (s *Stack[T]) Record(label string, before T) {
+	if s == nil {
+		return
+	}
+	s.undo = push(s.undo, Snapshot[T]{Label: label, State: before}, s.depth)
+	s.redo = nil
+}
+
+// Undo returns the state to restore and remembers current so the change can be redone
+func (s *Stack[T]) Undo(current T) (Snapshot[T], bool) {
+	if s == nil || len(s.undo) == 0 {
+		return Snapshot[T]{}, false
+	}
+	snapshot := s.undo[len(s.undo)-1]
+	s.undo = s.undo[:len(s.undo)-1]
+	s.redo = push(s.redo, Snapshot[T]{Label: snapshot.Label, State: current}, s.depth)
+	return snapshot, true
+}
+
+// Redo returns the state to restore and remembers current so the change can be undone again
+func (s *Stack[T]) Redo(current T) (Snapshot[T], bool) {
+	if s == nil || len(s.redo) == 0 {
+		return Snapshot[T]{}, false
+	}
+	snapshot := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	s.undo = push(s.undo, Snapshot[T]{Label: snapshot.Label, State: current}, s.depth)
+	return snapshot, true
+}
+
+func (s *Stack[T]) CanUndo() bool {
+	return s != nil && len(s.undo) > 0
+}
+
+func (s *Stack[T]) CanRedo() bool {
+	return s != nil && len(s.redo) > 0
+}
+
+func push[T any](snapshots []Snapshot[T], snapshot Snapshot[T], depth int) []Snapshot[T] {
+	snapshots = append(snapshots, snapshot)
+	if len(snapshots) > depth {
+		snapshots = append(snapshots[:0:0], snapshots[len(snapshots)-depth:]...)
+	}
+	return snapshots
+}
@@ -0,0 +1,71 @@
+package undo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoRedo(t *testing.T) {
+	s := New[[]string](10)
+	queue := []string{"a"}
+
+	s.Record("add b", queue)
+	queue = []string{"a", "b"}
+	s.Record("clear", queue)
+	queue = nil
+
+	snapshot, ok := s.Undo(queue)
+	assert.True(t, ok)
+	assert.Equal(t, "clear", snapshot.Label)
+	assert.Equal(t, []string{"a", "b"}, snapshot.State)
+	queue = snapshot.State
+
+	snapshot, ok = s.Undo(queue)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, snapshot.State)
+	queue = snapshot.State
+	assert.False(t, s.CanUndo())
+
+	snapshot, ok = s.Redo(queue)
+	assert.True(t, ok)
+	assert.Equal(t, "add b", snapshot.Label)
+	assert.Equal(t, []string{"a", "b"}, snapshot.State)
+	assert.True(t, s.CanRedo())
+}
+
+func TestRecordClearsRedo(t *testing.T) {
+	s := New[int](10)
+	s.Record("one", 0)
+	_, _ = s.Undo(1)
+	assert.True(t, s.CanRedo())
+
+	s.Record("two", 0)
+	assert.False(t, s.CanRedo())
+	_, ok := s.Redo(0)
+	assert.False(t, ok)
+}
+
+func TestDepthIsBounded(t *testing.T) {
+	s := New[int](3)
+	for i := range 5 {
+		s.Record("change", i)
+	}
+
+	var restored []int
+	current := 5
+	for s.CanUndo() {
+		snapshot, _ := s.Undo(current)
+		current = snapshot.State
+		restored = append(restored, current)
+	}
+	assert.Equal(t, []int{4, 3, 2}, restored)
+}
+
+func TestNilStack(t *testing.T) {
+	var s *Stack[int]
+	s.Record("change", 1)
+	_, ok := s.Undo(1)
+	assert.False(t, ok)
+	assert.False(t, s.CanRedo())
+}
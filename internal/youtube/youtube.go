@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ebitengine/oto/v3"
@@ -41,9 +43,13 @@ type CoreDepsPath struct {
 	FFmpeg string
 }
 
+// SearchAndDownloadMusic streams the track from start at volume, a start of zero plays it from the beginning and a
+// volume of 1 at full volume
 func SearchAndDownloadMusic(
 	ctx context.Context,
 	videoID string,
+	start time.Duration,
+	volume float64,
 	coreDepsPath *CoreDepsPath,
 	getStreamURL func() (string, error),
 ) tea.Cmd {
@@ -84,19 +90,25 @@ func SearchAndDownloadMusic(
 			}
 		}
 
-		ff, err := command.ExecCommand(ctx,
-			coreDepsPath.FFmpeg,
+		args := []string{
 			"-reconnect", "1",
 			"-reconnect_streamed", "1",
 			"-reconnect_delay_max", "5",
 			"-reconnect_on_network_error", "1",
 			"-reconnect_on_http_error", "1",
+		}
+		if start > 0 {
+			// before -i ffmpeg seeks in the stream instead of decoding everything up to start
+			args = append(args, "-ss", strconv.FormatFloat(start.Seconds(), 'f', 3, 64))
+		}
+		args = append(args,
 			"-i", streamURL,
 			"-f", "s16le",
 			"-ac", "2",
 			"-ar", "44100",
 			"pipe:1",
 		)
+		ff, err := command.ExecCommand(ctx, coreDepsPath.FFmpeg, args...)
 
 		if err != nil {
 			_ = ffStderr.Close()
@@ -175,11 +187,13 @@ func SearchAndDownloadMusic(
 		}
 
 		counter := &types.ByteCounterReader{
-			R: pr,
+			R:      pr,
+			Offset: start.Seconds(),
 		}
 
 		player := otoCtx.NewPlayer(counter)
 		player.SetBufferSize(0)
+		player.SetVolume(volume)
 		player.Play()
 
 		var once sync.Once